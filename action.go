@@ -1,9 +1,5 @@
 package zabbix
 
-import (
-	"strconv"
-)
-
 type (
 	// Whether to pause escalation during maintenance periods or not.
 	// "pause_suppressed" in https://www.zabbix.com/documentation/4.0/manual/api/reference/action/object#action
@@ -129,6 +125,368 @@ const (
 	ProxyExecutor  ActionOperationCommandExecutorType = 2
 )
 
+// MarshalJSON encodes t as a string.
+func (t PauseType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *PauseType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t ActionEvaluationType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ActionEvaluationType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t ActionConditionType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ActionConditionType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t ActionFilterConditionOperator) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ActionFilterConditionOperator) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t ActionOperationType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ActionOperationType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t ActionOperationCommandType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ActionOperationCommandType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t ActionOperationCommandAuthType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ActionOperationCommandAuthType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t ActionOperationCommandExecutorType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ActionOperationCommandExecutorType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var pauseTypeNames = enumNames{
+	kind: "pause type",
+	names: map[int][]string{
+		int(DontPause): {"Don't pause"},
+		int(Pause):     {"Pause"},
+	},
+}
+
+func (t PauseType) String() string {
+	return pauseTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t PauseType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *PauseType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParsePauseType(string(text))
+	return
+}
+
+// ParsePauseType Parses a pause type from its display name or number.
+func ParsePauseType(s string) (PauseType, error) {
+	v, err := pauseTypeNames.parse(s)
+	return PauseType(v), err
+}
+
+var actionEvaluationTypeNames = enumNames{
+	kind: "evaluation type",
+	names: map[int][]string{
+		int(AndOr):  {"And/Or"},
+		int(And):    {"And"},
+		int(Or):     {"Or"},
+		int(Custom): {"Custom expression", "Custom"},
+	},
+}
+
+func (t ActionEvaluationType) String() string {
+	return actionEvaluationTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ActionEvaluationType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ActionEvaluationType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseActionEvaluationType(string(text))
+	return
+}
+
+// ParseActionEvaluationType Parses an evaluation type from its display name or number.
+func ParseActionEvaluationType(s string) (ActionEvaluationType, error) {
+	v, err := actionEvaluationTypeNames.parse(s)
+	return ActionEvaluationType(v), err
+}
+
+var actionConditionTypeNames = enumNames{
+	kind: "condition type",
+	names: map[int][]string{
+		int(HostGroupCondition):                {"Host group"},
+		int(HostCondition):                     {"Host"},
+		int(TriggerCondition):                  {"Trigger"},
+		int(TriggerNameCondition):              {"Trigger name", "Event name"},
+		int(TriggerSeverityCondition):          {"Trigger severity"},
+		int(TimePeriodCondition):               {"Time period"},
+		int(HostIpCondition):                   {"Host IP"},
+		int(DiscoveredServiceTypeCondition):    {"Service type"},
+		int(DiscoveredServicePortCondition):    {"Service port"},
+		int(DiscoveryStatusCondition):          {"Discovery status"},
+		int(UptimeOrDowntimeDurationCondition): {"Uptime/Downtime"},
+		int(ReceivedValueCondition):            {"Received value"},
+		int(HostTemplateCondition):             {"Template"},
+		int(EventAcknowledgedCondition):        {"Event acknowledged"},
+		int(ApplicationCondition):              {"Application"},
+		int(ProblemIsSuppressedCondition):      {"Problem is suppressed"},
+		int(DiscoveryRuleCondition):            {"Discovery rule"},
+		int(DiscoveryCheckCondition):           {"Discovery check"},
+		int(ProxyCondition):                    {"Proxy"},
+		int(DiscoveryObjectCondition):          {"Discovery object"},
+		int(HostNameCondition):                 {"Host name"},
+		int(EventTypeCondition):                {"Event type"},
+		int(HostMetadataCondition):             {"Host metadata"},
+		int(EventTagCondition):                 {"Tag name", "Tag"},
+		int(EventTagValueCondition):            {"Tag value"},
+	},
+}
+
+func (t ActionConditionType) String() string {
+	return actionConditionTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ActionConditionType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ActionConditionType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseActionConditionType(string(text))
+	return
+}
+
+// ParseActionConditionType Parses a condition type from its display name or number.
+func ParseActionConditionType(s string) (ActionConditionType, error) {
+	v, err := actionConditionTypeNames.parse(s)
+	return ActionConditionType(v), err
+}
+
+var actionFilterConditionOperatorNames = enumNames{
+	kind: "condition operator",
+	names: map[int][]string{
+		int(Equals):                {"equals"},
+		int(DoesNotEqual):          {"does not equal"},
+		int(Contains):              {"contains"},
+		int(DoesNotContains):       {"does not contain"},
+		int(In):                    {"in"},
+		int(IsGreaterThanOrEquals): {"is greater than or equals"},
+		int(IsLessThanOrEquals):    {"is less than or equals"},
+		int(NotIn):                 {"not in"},
+		int(Matches):               {"matches"},
+		int(DoesNotMatch):          {"does not match"},
+		int(Yes):                   {"Yes"},
+		int(No):                    {"No"},
+	},
+}
+
+func (t ActionFilterConditionOperator) String() string {
+	return actionFilterConditionOperatorNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ActionFilterConditionOperator) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ActionFilterConditionOperator) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseActionFilterConditionOperator(string(text))
+	return
+}
+
+// ParseActionFilterConditionOperator Parses a condition operator from its display name or number.
+func ParseActionFilterConditionOperator(s string) (ActionFilterConditionOperator, error) {
+	v, err := actionFilterConditionOperatorNames.parse(s)
+	return ActionFilterConditionOperator(v), err
+}
+
+var actionOperationTypeNames = enumNames{
+	kind: "operation type",
+	names: map[int][]string{
+		int(SendMessage):               {"Send message"},
+		int(RemoteCommand):             {"Remote command"},
+		int(AddHost):                   {"Add host"},
+		int(RemoveHost):                {"Remove host"},
+		int(AddToHostGroup):            {"Add to host group"},
+		int(RemoveFromHostGroup):       {"Remove from host group"},
+		int(LinkToTemplate):            {"Link to template"},
+		int(UnlinkFromTemplate):        {"Unlink from template"},
+		int(EnableHost):                {"Enable host"},
+		int(DisableHost):               {"Disable host"},
+		int(SetHostInventoryMode):      {"Set host inventory mode"},
+		int(NotifyRecoveryAllInvolved): {"Notify all involved of recovery"},
+		int(NotifyUpdateAllInvolved):   {"Notify all involved of update"},
+	},
+}
+
+func (t ActionOperationType) String() string {
+	return actionOperationTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ActionOperationType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ActionOperationType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseActionOperationType(string(text))
+	return
+}
+
+// ParseActionOperationType Parses an operation type from its display name or number.
+func ParseActionOperationType(s string) (ActionOperationType, error) {
+	v, err := actionOperationTypeNames.parse(s)
+	return ActionOperationType(v), err
+}
+
+var actionOperationCommandTypeNames = enumNames{
+	kind: "command type",
+	names: map[int][]string{
+		int(CustomScript):  {"Custom script"},
+		int(IpmiCommand):   {"IPMI"},
+		int(SshCommand):    {"SSH"},
+		int(TelnetCommand): {"Telnet"},
+		int(GlobalScript):  {"Global script"},
+	},
+}
+
+func (t ActionOperationCommandType) String() string {
+	return actionOperationCommandTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ActionOperationCommandType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ActionOperationCommandType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseActionOperationCommandType(string(text))
+	return
+}
+
+// ParseActionOperationCommandType Parses a command type from its display name or number.
+func ParseActionOperationCommandType(s string) (ActionOperationCommandType, error) {
+	v, err := actionOperationCommandTypeNames.parse(s)
+	return ActionOperationCommandType(v), err
+}
+
+var actionOperationCommandAuthTypeNames = enumNames{
+	kind: "authentication method",
+	names: map[int][]string{
+		int(Password):  {"Password"},
+		int(PublicKey): {"Public key"},
+	},
+}
+
+func (t ActionOperationCommandAuthType) String() string {
+	return actionOperationCommandAuthTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ActionOperationCommandAuthType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ActionOperationCommandAuthType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseActionOperationCommandAuthType(string(text))
+	return
+}
+
+// ParseActionOperationCommandAuthType Parses an authentication method from its display name or number.
+func ParseActionOperationCommandAuthType(s string) (ActionOperationCommandAuthType, error) {
+	v, err := actionOperationCommandAuthTypeNames.parse(s)
+	return ActionOperationCommandAuthType(v), err
+}
+
+var actionOperationCommandExecutorTypeNames = enumNames{
+	kind: "execution target",
+	names: map[int][]string{
+		int(AgentExecutor):  {"Zabbix agent"},
+		int(ServerExecutor): {"Zabbix server"},
+		int(ProxyExecutor):  {"Zabbix server (proxy)"},
+	},
+}
+
+func (t ActionOperationCommandExecutorType) String() string {
+	return actionOperationCommandExecutorTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ActionOperationCommandExecutorType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ActionOperationCommandExecutorType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseActionOperationCommandExecutorType(string(text))
+	return
+}
+
+// ParseActionOperationCommandExecutorType Parses an execution target from its display name or number.
+func ParseActionOperationCommandExecutorType(s string) (ActionOperationCommandExecutorType, error) {
+	v, err := actionOperationCommandExecutorTypeNames.parse(s)
+	return ActionOperationCommandExecutorType(v), err
+}
+
 // Action represent Zabbix Action type returned from Zabbix API
 // https://www.zabbix.com/documentation/4.0/manual/api/reference/action/object
 // https://www.zabbix.com/documentation/5.0/manual/api/reference/action/object
@@ -137,14 +495,14 @@ type Action struct {
 	Period          string     `json:"esc_period,omitempty"`
 	EventSource     EventType  `json:"eventsource,omitempty"` // NOTE: Can not update
 	Name            string     `json:"name"`
-	DefaultMessage  string     `json:"def_longdata,omitempty"`  // NOTE: no support on Zabbix 5.0 onward
-	DefaultSubject  string     `json:"def_shortdata,omitempty"` // NOTE: no support on Zabbix 5.0 onward
-	RecoveryMessage string     `json:"r_longdata,omitempty"`    // NOTE: no support on Zabbix 5.0 onward
-	RecoverySubject string     `json:"r_shortdata,omitempty"`   // NOTE: no support on Zabbix 5.0 onward
-	AckMessage      string     `json:"ack_longdata,omitempty"`  // NOTE: no support on Zabbix 5.0 onward
-	AckSubject      string     `json:"ack_shortdata,omitempty"` // NOTE: no support on Zabbix 5.0 onward
-	Status          StatusType `json:"status,omitempty,string"`
-	PauseSuppressed *PauseType `json:"pause_suppressed,omitempty,string"`
+	DefaultMessage  string     `json:"def_longdata,omitempty"`  // NOTE: dropped on Zabbix 5.0 onward
+	DefaultSubject  string     `json:"def_shortdata,omitempty"` // NOTE: dropped on Zabbix 5.0 onward
+	RecoveryMessage string     `json:"r_longdata,omitempty"`    // NOTE: dropped on Zabbix 5.0 onward
+	RecoverySubject string     `json:"r_shortdata,omitempty"`   // NOTE: dropped on Zabbix 5.0 onward
+	AckMessage      string     `json:"ack_longdata,omitempty"`  // NOTE: dropped on Zabbix 5.0 onward
+	AckSubject      string     `json:"ack_shortdata,omitempty"` // NOTE: dropped on Zabbix 5.0 onward
+	Status          StatusType `json:"status,omitempty"`
+	PauseSuppressed *PauseType `json:"pause_suppressed,omitempty"`
 
 	Filter             ActionFilter             `json:"filter,omitempty"`
 	Operations         ActionOperations         `json:"operations,omitempty"`
@@ -154,31 +512,41 @@ type Action struct {
 
 type Actions []Action
 
+// actionFields adapts Action fields to the server version
+var actionFields = fieldRules{
+	{Field: "def_longdata", Feature: FeatureActionDefaultMessages},
+	{Field: "def_shortdata", Feature: FeatureActionDefaultMessages},
+	{Field: "r_longdata", Feature: FeatureActionDefaultMessages},
+	{Field: "r_shortdata", Feature: FeatureActionDefaultMessages},
+	{Field: "ack_longdata", Feature: FeatureActionDefaultMessages},
+	{Field: "ack_shortdata", Feature: FeatureActionDefaultMessages},
+}
+
 type ActionFilter struct {
 	Conditions     ActionFilterConditions `json:"conditions"`
-	EvaluationType ActionEvaluationType   `json:"evaltype,string"`
+	EvaluationType ActionEvaluationType   `json:"evaltype"`
 	Formula        string                 `json:"formula,omitempty"`
 }
 
 type ActionFilterCondition struct {
 	ConditionID   string                        `json:"conditionid,omitempty"`
-	ConditionType ActionConditionType           `json:"conditiontype,string"`
+	ConditionType ActionConditionType           `json:"conditiontype"`
 	Value         string                        `json:"value"`
 	Value2        string                        `json:"value2,omitempty"`
 	FormulaID     string                        `json:"formulaid,omitempty"`
-	Operator      ActionFilterConditionOperator `json:"operator,string"`
+	Operator      ActionFilterConditionOperator `json:"operator"`
 }
 
 type ActionFilterConditions []ActionFilterCondition
 
 type ActionOperation struct {
 	OperationID       string                           `json:"operationid,omitempty"`
-	OperationType     ActionOperationType              `json:"operationtype,string"`
+	OperationType     ActionOperationType              `json:"operationtype"`
 	ActionID          string                           `json:"actionid,omitempty"`
 	Period            string                           `json:"esc_period,omitempty"`
-	StepFrom          int                              `json:"esc_step_from,omitempty,string"`
-	StepTo            int                              `json:"esc_step_to,omitempty,string"`
-	EvaluationType    ActionEvaluationType             `json:"evaltype,omitempty,string"`
+	StepFrom          Int                              `json:"esc_step_from,omitempty"`
+	StepTo            Int                              `json:"esc_step_to,omitempty"`
+	EvaluationType    ActionEvaluationType             `json:"evaltype,omitempty"`
 	Command           *ActionOperationCommand          `json:"opcommand,omitempty"`
 	CommandHostGroups ActionOperationCommandHostGroups `json:"opcommand_grp,omitempty"`
 	CommandHosts      ActionOperationCommandHosts      `json:"opcommand_hst,omitempty"`
@@ -195,10 +563,10 @@ type ActionOperations []ActionOperation
 
 type ActionOperationCommand struct {
 	OperationID string                              `json:"operationid,omitempty"`
-	Type        ActionOperationCommandType          `json:"type,string,omitempty"`
+	Type        ActionOperationCommandType          `json:"type,omitempty"`
 	Command     string                              `json:"command,omitempty"`
-	AuthType    *ActionOperationCommandAuthType     `json:"authtype,omitempty,string"`
-	ExecuteOn   *ActionOperationCommandExecutorType `json:"execute_on,omitempty,string"`
+	AuthType    *ActionOperationCommandAuthType     `json:"authtype,omitempty"`
+	ExecuteOn   *ActionOperationCommandExecutorType `json:"execute_on,omitempty"`
 	Username    string                              `json:"username,omitempty"`
 	Password    string                              `json:"password,omitempty"`
 	Port        string                              `json:"port,omitempty"`
@@ -226,9 +594,9 @@ type ActionOperationCommandHosts []ActionOperationCommandHost
 type ActionOperationCondition struct {
 	OperationID string                        `json:"operationid,omitempty"`
 	ConditionID string                        `json:"opconditionid,omitempty"`
-	Condition   ActionConditionType           `json:"conditiontype,string"`
+	Condition   ActionConditionType           `json:"conditiontype"`
 	Value       string                        `json:"value"`
-	Operator    ActionFilterConditionOperator `json:"operator,string"`
+	Operator    ActionFilterConditionOperator `json:"operator"`
 }
 
 type ActionOperationConditions []ActionOperationCondition
@@ -276,7 +644,7 @@ type ActionOperationInventory struct {
 
 type ActionRecoveryOperation struct {
 	OperationID       string                           `json:"operationid,omitempty"`
-	OperationType     ActionOperationType              `json:"operationtype,string"`
+	OperationType     ActionOperationType              `json:"operationtype"`
 	ActionID          string                           `json:"actionid,omitempty"`
 	Command           *ActionOperationCommand          `json:"opcommand,omitempty"`
 	CommandHostGroups ActionOperationCommandHostGroups `json:"opcommand_grp,omitempty"`
@@ -290,7 +658,7 @@ type ActionRecoveryOperations []ActionRecoveryOperation
 
 type ActionUpdateOperation struct {
 	OperationID       string                           `json:"operationid,omitempty"`
-	OperationType     ActionOperationType              `json:"operationtype,string"`
+	OperationType     ActionOperationType              `json:"operationtype"`
 	Command           *ActionOperationCommand          `json:"opcommand,omitempty"`
 	CommandHostGroups ActionOperationCommandHostGroups `json:"opcommand_grp,omitempty"`
 	CommandHosts      ActionOperationCommandHosts      `json:"opcommand_hst,omitempty"`
@@ -337,20 +705,17 @@ func (api *API) ActionGetByID(id string) (res *Action, err error) {
 // ActionsCreate Wrapper for action.create
 // https://www.zabbix.com/documentation/4.0/manual/api/reference/action/create
 func (api *API) ActionsCreate(actions Actions) (err error) {
-	response, err := api.CallWithError("action.create", actions)
+	params, err := api.marshalFor(actions, actionFields)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("action.create", params)
 	if err != nil {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	actionids := result["actionids"].([]interface{})
-	for i, id := range actionids {
-		switch id.(type) {
-		case string:
-			actions[i].ActionID = id.(string)
-		case float64:
-			actions[i].ActionID = strconv.Itoa(int(id.(float64)))
-		}
+	for i, id := range resultIDs(response.Result, "actionids") {
+		actions[i].ActionID = id
 	}
 	return
 }
@@ -358,7 +723,11 @@ func (api *API) ActionsCreate(actions Actions) (err error) {
 // ActionsUpdate Wrapper for action.update
 // https://www.zabbix.com/documentation/4.0/manual/api/reference/action/update
 func (api *API) ActionsUpdate(actions Actions) (err error) {
-	_, err = api.CallWithError("action.update", actions)
+	params, err := api.marshalFor(actions, actionFields)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("action.update", params)
 	return
 }
 
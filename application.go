@@ -15,6 +15,9 @@ type Applications []Application
 // ApplicationsGet Wrapper for application.get
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/application/get
 func (api *API) ApplicationsGet(params Params) (res Applications, err error) {
+	if err = api.requires(FeatureApplications); err != nil {
+		return
+	}
 	if _, present := params["output"]; !present {
 		params["output"] = "extend"
 	}
@@ -61,15 +64,16 @@ func (api *API) ApplicationGetByHostIDAndName(hostID, name string) (res *Applica
 // ApplicationsCreate Wrapper for application.create
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/application/create
 func (api *API) ApplicationsCreate(apps Applications) (err error) {
+	if err = api.requires(FeatureApplications); err != nil {
+		return
+	}
 	response, err := api.CallWithError("application.create", apps)
 	if err != nil {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	applicationids := result["applicationids"].([]interface{})
-	for i, id := range applicationids {
-		apps[i].ApplicationID = id.(string)
+	for i, id := range resultIDs(response.Result, "applicationids") {
+		apps[i].ApplicationID = id
 	}
 	return
 }
@@ -95,6 +99,9 @@ func (api *API) ApplicationsDelete(apps Applications) (err error) {
 // ApplicationsDeleteByIds Wrapper for application.delete
 // https://www.zabbix.com/documentation/2.2/manual/appendix/api/application/delete
 func (api *API) ApplicationsDeleteByIds(ids []string) (err error) {
+	if err = api.requires(FeatureApplications); err != nil {
+		return
+	}
 	response, err := api.CallWithError("application.delete", ids)
 	if err != nil {
 		return
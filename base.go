@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"sync/atomic"
 
 	"github.com/hashicorp/go-version"
@@ -109,14 +110,70 @@ func (api *API) printf(format string, v ...interface{}) {
 	}
 }
 
+// secretFields are the request fields holding passwords, masked in the log.
+var secretFields = map[string]bool{"passwd": true, "current_passwd": true, "password": true}
+
+// maskSecrets returns the request body with the passwords and the values of secret macros masked,
+// like Macro.String does, for the log.
+func maskSecrets(body []byte) []byte {
+	var request interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&request); err != nil || !maskValues(request) {
+		return body
+	}
+	if masked, err := json.Marshal(request); err == nil {
+		return masked
+	}
+	return body
+}
+
+// maskValues masks the secrets in a decoded JSON value, telling if there were any.
+func maskValues(value interface{}) (masked bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if _, ok := field.(string); ok && secretFields[key] {
+				v[key], masked = macroMask, true
+			} else if maskValues(field) {
+				masked = true
+			}
+		}
+		if _, ok := v["value"]; ok && v["macro"] != nil && fmt.Sprint(v["type"]) == fmt.Sprint(int(MacroSecret)) {
+			v["value"], masked = macroMask, true
+		}
+	case []interface{}:
+		for _, item := range v {
+			if maskValues(item) {
+				masked = true
+			}
+		}
+	}
+	return
+}
+
+// basicAuth tells if the URL carries basic auth credentials.
+func (api *API) basicAuth() bool {
+	u, err := url.Parse(api.url)
+	return err == nil && u.User != nil
+}
+
 func (api *API) callBytes(method string, params interface{}) (b []byte, err error) {
 	id := atomic.AddInt32(&api.id, 1)
 	jsonobj := request{"2.0", method, params, api.Auth, id}
+	// From 6.4 the token goes in the Authorization header, the auth property being deprecated and then removed in 7.2.
+	// Basic auth credentials of the URL use the same header, the token then stays in the auth property.
+	bearer := api.Auth != "" && api.Supports(FeatureBearerAuth) && !api.basicAuth()
+	if bearer {
+		jsonobj.Auth = ""
+	}
 	b, err = json.Marshal(jsonobj)
 	if err != nil {
 		return
 	}
-	api.printf("Request (POST): %s", b)
+	if api.Logger != nil {
+		api.printf("Request (POST): %s", maskSecrets(b))
+	}
 
 	req, err := http.NewRequest("POST", api.url, bytes.NewReader(b))
 	if err != nil {
@@ -125,6 +182,9 @@ func (api *API) callBytes(method string, params interface{}) (b []byte, err erro
 	req.ContentLength = int64(len(b))
 	req.Header.Add("Content-Type", "application/json-rpc")
 	req.Header.Add("User-Agent", api.UserAgent)
+	if bearer {
+		req.Header.Add("Authorization", "Bearer "+api.Auth)
+	}
 
 	res, err := api.c.Do(req)
 	if err != nil {
@@ -173,7 +233,7 @@ func (api *API) CallWithErrorParse(method string, params interface{}, result int
 	if rawResult.Error != nil {
 		return rawResult.Error
 	}
-	err = json.Unmarshal(rawResult.Result, &result)
+	err = unmarshalFlexible(rawResult.Result, result)
 	return
 }
 
@@ -181,7 +241,7 @@ func (api *API) CallWithErrorParse(method string, params interface{}, result int
 // This method modifies API structure and should not be called concurrently with other methods.
 func (api *API) Login(user, password string) (auth string, err error) {
 	var response Response
-	if api.ServerVersion.GreaterThan(version.Must(version.NewVersion("5.4"))) {
+	if api.Supports(FeatureUsername) {
 		response, err = api.CallWithError("user.login", map[string]string{"username": user, "password": password})
 	} else {
 		response, err = api.CallWithError("user.login", map[string]string{"user": user, "password": password})
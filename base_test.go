@@ -1,11 +1,16 @@
 package zabbix_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"log"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -68,6 +73,42 @@ func testGetAPI(t *testing.T) *zapi.API {
 	return _api
 }
 
+// testFakeHandler answers a call to a fake server with a raw JSON result or an error.
+type testFakeHandler func(r *http.Request, method string, params json.RawMessage) (result string, err *zapi.Error)
+
+// testFakeAPI returns an API connected to a fake server of the given version, for tests not needing a real server.
+func testFakeAPI(t *testing.T, serverVersion string, handle testFakeHandler) *zapi.API {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+			ID     int32           `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result, e := fmt.Sprintf("%q", serverVersion), (*zapi.Error)(nil)
+		if req.Method != "APIInfo.version" {
+			result, e = handle(r, req.Method, req.Params)
+		}
+		if e != nil {
+			b, _ := json.Marshal(e)
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","error":%s,"id":%d}`, b, req.ID)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%s,"id":%d}`, result, req.ID)
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := zapi.NewAPI(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api
+}
+
 func compVersion(t *testing.T, comparedVersion string) (int, string) {
 	api := testGetAPI(t)
 	serverVersion, err := api.Version()
@@ -123,3 +164,83 @@ func TestVersion(t *testing.T) {
 		t.Errorf("Unexpected version: %s", v)
 	}
 }
+
+func TestAuthPlacement(t *testing.T) {
+	var header, body string
+	var basic bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			Auth   string `json:"auth"`
+			ID     int32  `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		header, body = r.Header.Get("Authorization"), req.Auth
+		_, _, basic = r.BasicAuth()
+		result := `"6.4.0"`
+		if req.Method != "APIInfo.version" {
+			result = "[]"
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%s,"id":%d}`, result, req.ID)
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		url    string
+		bearer bool
+	}{
+		{server.URL, true},
+		{strings.Replace(server.URL, "http://", "http://user:password@", 1), false},
+	}
+	for _, c := range cases {
+		api, err := zapi.NewAPI(c.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		api.Auth = "token"
+		if _, err = api.HostsGet(zapi.Params{}); err != nil {
+			t.Fatal(err)
+		}
+		if c.bearer && (header != "Bearer token" || body != "") {
+			t.Errorf("%s: token should be in the Authorization header, got header %q and auth %q", c.url, header, body)
+		}
+		if !c.bearer && (!basic || body != "token") {
+			t.Errorf("%s: basic auth should be kept and the token in the auth property, got header %q and auth %q", c.url, header, body)
+		}
+	}
+}
+
+func TestRequestLogMasking(t *testing.T) {
+	api := testFakeAPI(t, "6.0.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		if method == "user.login" {
+			return `"token"`, nil
+		}
+		return `{"userids":["1"],"hostids":["1"]}`, nil
+	})
+	var buf bytes.Buffer
+	api.Logger = log.New(&buf, "", 0)
+
+	if _, err := api.Login("Admin", "hunter1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.UsersUpdate(zapi.Users{{UserID: "1", Password: "hunter2", CurrentPassword: "hunter3"}}); err != nil {
+		t.Fatal(err)
+	}
+	macros := zapi.Macros{{MacroName: "{$SECRET}", Value: "hunter4", Type: zapi.MacroSecret}, {MacroName: "{$TEXT}", Value: "visible"}}
+	if err := api.HostsUpdate(zapi.Hosts{{HostID: "1", Host: "a", UserMacros: macros}}); err != nil {
+		t.Fatal(err)
+	}
+
+	logged := buf.String()
+	for _, secret := range []string{"hunter1", "hunter2", "hunter3", "hunter4"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("Secret %s is logged:\n%s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, "visible") {
+		t.Errorf("Text macro value is masked:\n%s", logged)
+	}
+}
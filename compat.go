@@ -0,0 +1,142 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// fieldRule adapts a JSON field of an object to servers not providing a feature.
+type fieldRule struct {
+	// Field is the name of the field on servers providing Feature.
+	Field   string
+	Feature Feature
+	// Legacy is the name of the field on other servers, empty if they do not know the field at all.
+	Legacy string
+	// Convert converts the value to its legacy format, if it differs.
+	Convert func(value interface{}) interface{}
+	// Restore converts a legacy value back to its current format, when Convert is set.
+	Restore func(value interface{}) interface{}
+}
+
+// fieldRules is an array of fieldRule
+type fieldRules []fieldRule
+
+// marshalFor converts objects, a struct or a slice of structs, to generic JSON values
+// whose fields are renamed or dropped according to the server version, and left as they are if it is unknown.
+func (api *API) marshalFor(objects interface{}, rules fieldRules) (res interface{}, err error) {
+	b, err := json.Marshal(objects)
+	if err != nil {
+		return
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err = d.Decode(&res); err != nil {
+		return
+	}
+
+	switch v := res.(type) {
+	case []interface{}:
+		for _, o := range v {
+			if m, ok := o.(map[string]interface{}); ok {
+				api.adaptFields(m, rules)
+			}
+		}
+	case map[string]interface{}:
+		api.adaptFields(v, rules)
+	}
+	return
+}
+
+func (api *API) adaptFields(object map[string]interface{}, rules fieldRules) {
+	for _, rule := range rules {
+		value, present := object[rule.Field]
+		if !present || !api.legacy(rule.Feature) {
+			continue
+		}
+		delete(object, rule.Field)
+		if rule.Legacy == "" {
+			continue
+		}
+		if rule.Convert != nil {
+			value = rule.Convert(value)
+		}
+		object[rule.Legacy] = value
+	}
+}
+
+// objectIDs returns a Convert function flattening an array of objects to the array of their key property,
+// such as [{"groupid": "1"}] to ["1"].
+func objectIDs(key string) func(interface{}) interface{} {
+	return func(value interface{}) interface{} {
+		objects, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		ids := make([]interface{}, 0, len(objects))
+		for _, o := range objects {
+			if m, ok := o.(map[string]interface{}); ok {
+				ids = append(ids, m[key])
+			}
+		}
+		return ids
+	}
+}
+
+// mapValue returns a Convert or Restore function replacing the values found in table, such as enumeration values
+// renumbered from a version to another.
+func mapValue(table map[string]string) func(interface{}) interface{} {
+	return func(value interface{}) interface{} {
+		if mapped, ok := table[fmt.Sprint(value)]; ok {
+			return mapped
+		}
+		return value
+	}
+}
+
+// canonical renames legacy fields of a JSON object to their current name,
+// so that the object decodes the same whatever the server version.
+func (rules fieldRules) canonical(data []byte) ([]byte, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		// not an object, let the caller report it
+		return data, nil
+	}
+
+	renamed := false
+	for _, rule := range rules {
+		if rule.Legacy == "" {
+			continue
+		}
+		value, present := object[rule.Legacy]
+		if !present {
+			continue
+		}
+		if _, current := object[rule.Field]; !current {
+			if rule.Restore != nil {
+				if value, err := restore(value, rule.Restore); err == nil {
+					object[rule.Field] = value
+				}
+			} else {
+				object[rule.Field] = value
+			}
+		}
+		delete(object, rule.Legacy)
+		renamed = true
+	}
+	if !renamed {
+		return data, nil
+	}
+	return json.Marshal(object)
+}
+
+// restore applies a Restore function to a raw JSON value.
+func restore(value json.RawMessage, f func(interface{}) interface{}) (json.RawMessage, error) {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(value))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(f(v))
+}
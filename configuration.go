@@ -0,0 +1,257 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+type (
+	// ConfigurationFormat Format of exported and imported configuration
+	// see "format" in https://www.zabbix.com/documentation/6.0/manual/api/reference/configuration/export
+	ConfigurationFormat string
+)
+
+const (
+	// FormatYAML YAML, new in 5.2
+	FormatYAML ConfigurationFormat = "yaml"
+	// FormatXML XML
+	FormatXML ConfigurationFormat = "xml"
+	// FormatJSON JSON
+	FormatJSON ConfigurationFormat = "json"
+	// FormatRaw unprocessed JSON, for export only
+	FormatRaw ConfigurationFormat = "raw"
+)
+
+// ExportOptions are the IDs of the objects to export, by kind
+// see "options" in https://www.zabbix.com/documentation/6.0/manual/api/reference/configuration/export
+type ExportOptions struct {
+	// NOTE: host groups and template groups are exported as groups before 6.2
+	HostGroups     []string
+	TemplateGroups []string
+	Hosts          []string
+	Images         []string
+	Maps           []string
+	MediaTypes     []string
+	Templates      []string
+	// NOTE: removed in 5.4, value maps are exported with their hosts and templates, ignored since then
+	ValueMaps []string
+}
+
+// exportOptions returns the options parameter of configuration.export for the server version.
+func (api *API) exportOptions(o ExportOptions) map[string][]string {
+	options := make(map[string][]string)
+	add := func(key string, ids []string) {
+		if len(ids) > 0 {
+			options[key] = append(options[key], ids...)
+		}
+	}
+	if !api.legacy(FeatureTemplateGroups) {
+		add("host_groups", o.HostGroups)
+		add("template_groups", o.TemplateGroups)
+	} else {
+		add("groups", o.HostGroups)
+		add("groups", o.TemplateGroups)
+	}
+	add("hosts", o.Hosts)
+	add("images", o.Images)
+	add("maps", o.Maps)
+	add("mediaTypes", o.MediaTypes)
+	add("templates", o.Templates)
+	if api.legacy(FeatureHostValueMaps) {
+		add("valueMaps", o.ValueMaps)
+	}
+	return options
+}
+
+// requiresFormat returns an UnsupportedFeature error if the server does not know the format.
+func (api *API) requiresFormat(format ConfigurationFormat) error {
+	if format == FormatYAML {
+		return api.requires(FeatureYAMLFormat)
+	}
+	return nil
+}
+
+// ConfigurationExport Wrapper for configuration.export
+// Returns the configuration of the given objects in the given format.
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/configuration/export
+func (api *API) ConfigurationExport(options ExportOptions, format ConfigurationFormat) (res string, err error) {
+	if err = api.requiresFormat(format); err != nil {
+		return
+	}
+	err = api.CallWithErrorParse("configuration.export", Params{
+		"options": api.exportOptions(options),
+		"format":  format,
+	}, &res)
+	return
+}
+
+// ImportRule tells what to do with a kind of imported objects
+// Objects of some kinds cannot be deleted or updated, the rule of a kind must then leave the matching flag unset.
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/configuration/import
+type ImportRule struct {
+	CreateMissing  bool `json:"createMissing,omitempty"`
+	UpdateExisting bool `json:"updateExisting,omitempty"`
+	DeleteMissing  bool `json:"deleteMissing,omitempty"`
+}
+
+// ImportRules are the rules of configuration.import by object kind, nil rules leaving objects of the kind untouched
+// see "rules" in https://www.zabbix.com/documentation/6.0/manual/api/reference/configuration/import
+type ImportRules struct {
+	// NOTE: host groups and template groups are imported with the groups rule before 6.2
+	HostGroups     *ImportRule `json:"host_groups,omitempty"`
+	TemplateGroups *ImportRule `json:"template_groups,omitempty"`
+
+	Hosts           *ImportRule `json:"hosts,omitempty"`
+	Templates       *ImportRule `json:"templates,omitempty"`
+	TemplateLinkage *ImportRule `json:"templateLinkage,omitempty"` // create and delete only
+	// NOTE: templateScreens before 5.2
+	TemplateDashboards *ImportRule `json:"templateDashboards,omitempty"`
+	// NOTE: removed in 5.4
+	Applications *ImportRule `json:"applications,omitempty"` // create and delete only
+
+	Items          *ImportRule `json:"items,omitempty"`
+	DiscoveryRules *ImportRule `json:"discoveryRules,omitempty"`
+	Triggers       *ImportRule `json:"triggers,omitempty"`
+	Graphs         *ImportRule `json:"graphs,omitempty"`
+	HTTPTests      *ImportRule `json:"httptests,omitempty"`
+	ValueMaps      *ImportRule `json:"valueMaps,omitempty"`
+
+	Images     *ImportRule `json:"images,omitempty"` // create and update only
+	Maps       *ImportRule `json:"maps,omitempty"`   // create and update only
+	MediaTypes *ImportRule `json:"mediaTypes,omitempty"`
+}
+
+var importRulesFields = fieldRules{
+	{Field: "templateDashboards", Feature: FeatureTemplateDashboards, Legacy: "templateScreens"},
+	{Field: "applications", Feature: FeatureApplications},
+}
+
+// importParams returns the parameters of configuration.import and configuration.importcompare for the server version.
+func (api *API) importParams(source string, format ConfigurationFormat, rules ImportRules) (params Params, err error) {
+	if err = api.requiresFormat(format); err != nil {
+		return
+	}
+	r, err := api.marshalFor(rules, importRulesFields)
+	if err != nil {
+		return
+	}
+	if object := r.(map[string]interface{}); api.legacy(FeatureTemplateGroups) {
+		// a single rule for both, allowing what either allows
+		groups := map[string]interface{}{}
+		for _, key := range []string{"host_groups", "template_groups"} {
+			if rule, ok := object[key].(map[string]interface{}); ok {
+				for flag, value := range rule {
+					groups[flag] = value
+				}
+			}
+			delete(object, key)
+		}
+		if len(groups) > 0 {
+			object["groups"] = groups
+		}
+	}
+	params = Params{
+		"format": format,
+		"rules":  r,
+		"source": source,
+	}
+	return
+}
+
+// ConfigurationImport Wrapper for configuration.import
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/configuration/import
+func (api *API) ConfigurationImport(source string, format ConfigurationFormat, rules ImportRules) (err error) {
+	params, err := api.importParams(source, format, rules)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("configuration.import", params)
+	return
+}
+
+// ImportUpdate is an object that an import would update
+type ImportUpdate struct {
+	Before map[string]interface{}
+	After  map[string]interface{}
+	// Changes are the changes of the objects it holds, such as the items of a template.
+	Changes ImportDiffs
+}
+
+// UnmarshalJSON decodes the before and after properties, the other ones being the changes of the objects it holds.
+func (u *ImportUpdate) UnmarshalJSON(data []byte) (err error) {
+	var object map[string]json.RawMessage
+	if err = json.Unmarshal(data, &object); err != nil {
+		return
+	}
+	*u = ImportUpdate{}
+	for key, value := range object {
+		switch key {
+		case "before":
+			err = json.Unmarshal(value, &u.Before)
+		case "after":
+			err = json.Unmarshal(value, &u.After)
+		default:
+			var diff ImportDiff
+			if err = json.Unmarshal(value, &diff); err == nil {
+				if u.Changes == nil {
+					u.Changes = make(ImportDiffs)
+				}
+				u.Changes[key] = diff
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// ImportDiff are the changes an import would make to a kind of objects
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/configuration/importcompare
+type ImportDiff struct {
+	Added   []map[string]interface{} `json:"added,omitempty"`
+	Removed []map[string]interface{} `json:"removed,omitempty"`
+	Updated []ImportUpdate           `json:"updated,omitempty"`
+}
+
+// ImportDiffs are the changes an import would make by object kind, such as "templates"
+type ImportDiffs map[string]ImportDiff
+
+// UnmarshalJSON accepts the empty array returned when nothing would change.
+func (d *ImportDiffs) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("[]")) {
+		*d = nil
+		return nil
+	}
+	var diffs map[string]ImportDiff
+	if err := json.Unmarshal(data, &diffs); err != nil {
+		return err
+	}
+	*d = diffs
+	return nil
+}
+
+// Empty Tells if the import would change nothing.
+func (d ImportDiffs) Empty() bool {
+	for _, diff := range d {
+		if len(diff.Added) > 0 || len(diff.Removed) > 0 || len(diff.Updated) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ConfigurationImportCompare Wrapper for configuration.importcompare
+// Returns the changes configuration.import would make with the same arguments.
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/configuration/importcompare
+func (api *API) ConfigurationImportCompare(source string, format ConfigurationFormat, rules ImportRules) (res ImportDiffs, err error) {
+	if err = api.requires(FeatureImportCompare); err != nil {
+		return
+	}
+	params, err := api.importParams(source, format, rules)
+	if err != nil {
+		return
+	}
+	err = api.CallWithErrorParse("configuration.importcompare", params, &res)
+	return
+}
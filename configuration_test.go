@@ -0,0 +1,83 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestImportDiffsDecoding(t *testing.T) {
+	var diffs zapi.ImportDiffs
+	if err := json.Unmarshal([]byte(`[]`), &diffs); err != nil {
+		t.Fatal(err)
+	}
+	if !diffs.Empty() {
+		t.Errorf("Unexpected changes: %#v", diffs)
+	}
+
+	data := `{"templates":{"updated":[{"before":{"template":"a"},"after":{"template":"a"},
+		"items":{"added":[{"key":"agent.ping"}],"removed":[{"key":"agent.version"}]}}]}}`
+	if err := json.Unmarshal([]byte(data), &diffs); err != nil {
+		t.Fatal(err)
+	}
+	if diffs.Empty() || len(diffs["templates"].Updated) != 1 {
+		t.Fatalf("Unexpected changes: %#v", diffs)
+	}
+	update := diffs["templates"].Updated[0]
+	if update.Before["template"] != "a" || update.After["template"] != "a" {
+		t.Errorf("Unexpected update: %#v", update)
+	}
+	items := update.Changes["items"]
+	if len(items.Added) != 1 || items.Added[0]["key"] != "agent.ping" || len(items.Removed) != 1 {
+		t.Errorf("Unexpected item changes: %#v", update.Changes)
+	}
+}
+
+func TestConfiguration(t *testing.T) {
+	api := testGetAPI(t)
+
+	// Zabbix v6.2 introduced Template Groups and requires them for Templates
+	var groupIds zapi.HostGroupIDs
+	if compLessThan, _ := isVersionLessThan(t, "6.2"); compLessThan {
+		hostGroup := testCreateHostGroup(t)
+		defer testDeleteHostGroup(hostGroup, t)
+		groupIds = zapi.HostGroupIDs{{GroupID: hostGroup.GroupID}}
+	} else {
+		templateGroup := testCreateTemplateGroup(t)
+		defer testDeleteTemplateGroup(templateGroup, t)
+		groupIds = zapi.HostGroupIDs{{GroupID: templateGroup.GroupID}}
+	}
+
+	template := testCreateTemplate(&groupIds, t)
+	defer testDeleteTemplate(template, t)
+
+	source, err := api.ConfigurationExport(zapi.ExportOptions{Templates: []string{template.TemplateID}}, zapi.FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(source, template.Host) {
+		t.Fatalf("Template is not exported: %s", source)
+	}
+
+	rules := zapi.ImportRules{
+		HostGroups:     &zapi.ImportRule{CreateMissing: true},
+		TemplateGroups: &zapi.ImportRule{CreateMissing: true},
+		Templates:      &zapi.ImportRule{CreateMissing: true, UpdateExisting: true},
+	}
+	if api.Supports(zapi.FeatureImportCompare) {
+		diffs, err := api.ConfigurationImportCompare(source, zapi.FormatJSON, rules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !diffs.Empty() {
+			t.Errorf("Importing the exported template should change nothing: %#v", diffs)
+		}
+	}
+
+	err = api.ConfigurationImport(source, zapi.FormatJSON, rules)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,685 @@
+package zabbix
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type (
+	// WidgetFieldType Type of the value of a dashboard widget field
+	// see "type" in https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/object#dashboard-widget-field
+	WidgetFieldType int
+
+	// TopHostsColumnData Data shown by a column of a top hosts widget, new in 6.0
+	// see "columns.data" in https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/widget_fields/top_hosts
+	TopHostsColumnData int
+)
+
+const (
+	// FieldInteger integer value
+	FieldInteger WidgetFieldType = 0
+	// FieldString string value
+	FieldString WidgetFieldType = 1
+	// FieldHostGroup host group ID
+	FieldHostGroup WidgetFieldType = 2
+	// FieldHost host ID
+	FieldHost WidgetFieldType = 3
+	// FieldItem item ID
+	FieldItem WidgetFieldType = 4
+	// FieldItemPrototype item prototype ID
+	FieldItemPrototype WidgetFieldType = 5
+	// FieldGraph graph ID
+	FieldGraph WidgetFieldType = 6
+	// FieldGraphPrototype graph prototype ID
+	FieldGraphPrototype WidgetFieldType = 7
+	// FieldMap map ID
+	FieldMap WidgetFieldType = 8
+	// FieldService service ID, new in 6.0
+	FieldService WidgetFieldType = 9
+	// FieldSLA SLA ID, new in 6.0
+	FieldSLA WidgetFieldType = 10
+	// FieldUser user ID, new in 6.4
+	FieldUser WidgetFieldType = 11
+	// FieldAction action ID, new in 6.4
+	FieldAction WidgetFieldType = 12
+	// FieldMediaType media type ID, new in 6.4
+	FieldMediaType WidgetFieldType = 13
+)
+
+const (
+	// ColumnItemValue value of the item named by the column
+	ColumnItemValue TopHostsColumnData = 1
+	// ColumnHostName name of the host
+	ColumnHostName TopHostsColumnData = 2
+	// ColumnText text of the column, which may contain macros
+	ColumnText TopHostsColumnData = 3
+)
+
+// MarshalJSON encodes t as a string.
+func (t WidgetFieldType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *WidgetFieldType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var widgetFieldTypeNames = enumNames{
+	kind: "widget field type",
+	names: map[int][]string{
+		int(FieldInteger):        {"Integer"},
+		int(FieldString):         {"String"},
+		int(FieldHostGroup):      {"Host group"},
+		int(FieldHost):           {"Host"},
+		int(FieldItem):           {"Item"},
+		int(FieldItemPrototype):  {"Item prototype"},
+		int(FieldGraph):          {"Graph"},
+		int(FieldGraphPrototype): {"Graph prototype"},
+		int(FieldMap):            {"Map"},
+		int(FieldService):        {"Service"},
+		int(FieldSLA):            {"SLA"},
+		int(FieldUser):           {"User"},
+		int(FieldAction):         {"Action"},
+		int(FieldMediaType):      {"Media type"},
+	},
+}
+
+func (t WidgetFieldType) String() string {
+	return widgetFieldTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t WidgetFieldType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *WidgetFieldType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseWidgetFieldType(string(text))
+	return
+}
+
+// ParseWidgetFieldType Parses a widget field type from its display name or number.
+func ParseWidgetFieldType(s string) (WidgetFieldType, error) {
+	v, err := widgetFieldTypeNames.parse(s)
+	return WidgetFieldType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t TopHostsColumnData) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *TopHostsColumnData) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var topHostsColumnDataNames = enumNames{
+	kind: "column data",
+	names: map[int][]string{
+		int(ColumnItemValue): {"Item value"},
+		int(ColumnHostName):  {"Host name"},
+		int(ColumnText):      {"Text"},
+	},
+}
+
+func (t TopHostsColumnData) String() string {
+	return topHostsColumnDataNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t TopHostsColumnData) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *TopHostsColumnData) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseTopHostsColumnData(string(text))
+	return
+}
+
+// ParseTopHostsColumnData Parses a column data from its display name or number.
+func ParseTopHostsColumnData(s string) (TopHostsColumnData, error) {
+	v, err := topHostsColumnDataNames.parse(s)
+	return TopHostsColumnData(v), err
+}
+
+// WidgetField represent Zabbix dashboard widget field object
+// Field names and types depend on the widget type and on the server version, see the NewXWidget builders.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/object#dashboard-widget-field
+type WidgetField struct {
+	Type  WidgetFieldType `json:"type"`
+	Name  string          `json:"name"`
+	Value string          `json:"value"`
+}
+
+// WidgetFields is an array of WidgetField
+type WidgetFields []WidgetField
+
+// Widget represent Zabbix dashboard widget object
+// Position and size are in grid cells, see ArrangeWidgets.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/object#dashboard-widget
+type Widget struct {
+	WidgetID string       `json:"widgetid,omitempty"` // Readonly
+	Type     string       `json:"type"`               // Required
+	Name     string       `json:"name"`
+	X        Int          `json:"x"`
+	Y        Int          `json:"y"`
+	Width    Int          `json:"width,omitempty"`
+	Height   Int          `json:"height,omitempty"`
+	ViewMode Int          `json:"view_mode,omitempty"` // 1 to hide the header
+	Fields   WidgetFields `json:"fields,omitempty"`
+}
+
+// Widgets is an array of Widget
+type Widgets []Widget
+
+// DashboardPage represent Zabbix dashboard page object
+// NOTE: new in 5.4, dashboards have a single page of widgets before
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/object#dashboard-page
+type DashboardPage struct {
+	PageID        string  `json:"dashboard_pageid,omitempty"` // Readonly
+	Name          string  `json:"name,omitempty"`
+	DisplayPeriod Int     `json:"display_period,omitempty"` // seconds, 0 for the period of the dashboard
+	Widgets       Widgets `json:"widgets,omitempty"`
+}
+
+// DashboardPages is an array of DashboardPage
+type DashboardPages []DashboardPage
+
+// DashboardUser represent Zabbix dashboard user object
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/object#dashboard-user
+type DashboardUser struct {
+	UserID     string         `json:"userid"`
+	Permission PermissionType `json:"permission"`
+}
+
+// DashboardUsers is an array of DashboardUser
+type DashboardUsers []DashboardUser
+
+// DashboardUserGroup represent Zabbix dashboard user group object
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/object#dashboard-user-group
+type DashboardUserGroup struct {
+	UserGroupID string         `json:"usrgrpid"`
+	Permission  PermissionType `json:"permission"`
+}
+
+// DashboardUserGroups is an array of DashboardUserGroup
+type DashboardUserGroups []DashboardUserGroup
+
+// Dashboard represent Zabbix dashboard object
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/object
+type Dashboard struct {
+	DashboardID string `json:"dashboardid,omitempty"` // Readonly
+	Name        string `json:"name"`                  // Required
+	UserID      string `json:"userid,omitempty"`      // owner, the current user by default
+	Private     Int    `json:"private"`               // 0 for a public dashboard, 1 for a private one
+
+	// NOTE: new in 5.4
+	DisplayPeriod Int  `json:"display_period,omitempty"` // seconds between pages of the slideshow
+	AutoStart     *Int `json:"auto_start,omitempty"`     // 0 to not start the slideshow, 1 by default
+
+	// Pages are required on creation, and returned with selectPages, or selectWidgets before 5.4.
+	Pages DashboardPages `json:"pages,omitempty"`
+	// Users and UserGroups the dashboard is shared with, returned with selectUsers and selectUserGroups.
+	Users      DashboardUsers      `json:"users,omitempty"`
+	UserGroups DashboardUserGroups `json:"userGroups,omitempty"`
+}
+
+// Dashboards is an array of Dashboard
+type Dashboards []Dashboard
+
+// TemplateDashboard represent Zabbix template dashboard object, new in 5.2
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/templatedashboard/object
+type TemplateDashboard struct {
+	DashboardID string `json:"dashboardid,omitempty"` // Readonly
+	TemplateID  string `json:"templateid,omitempty"`  // Required on creation only
+	Name        string `json:"name"`                  // Required
+
+	// NOTE: new in 5.4
+	DisplayPeriod Int  `json:"display_period,omitempty"`
+	AutoStart     *Int `json:"auto_start,omitempty"`
+
+	// Pages are required on creation, and returned with selectPages, or selectWidgets before 5.4.
+	Pages DashboardPages `json:"pages,omitempty"`
+}
+
+// TemplateDashboards is an array of TemplateDashboard
+type TemplateDashboards []TemplateDashboard
+
+var dashboardFields = fieldRules{
+	{Field: "display_period", Feature: FeatureDashboardPages},
+	{Field: "auto_start", Feature: FeatureDashboardPages},
+}
+
+// UnmarshalJSON decodes the widgets of dashboards before 5.4 as a single page.
+func (d *Dashboard) UnmarshalJSON(data []byte) (err error) {
+	type dashboard Dashboard
+	legacy := struct {
+		*dashboard
+		Widgets Widgets `json:"widgets"`
+	}{dashboard: (*dashboard)(d)}
+	if err = unmarshalFlexible(data, &legacy); err != nil {
+		return
+	}
+	if legacy.Widgets != nil {
+		d.Pages = DashboardPages{{Widgets: legacy.Widgets}}
+	}
+	return
+}
+
+// UnmarshalJSON decodes the widgets of template dashboards before 5.4 as a single page.
+func (d *TemplateDashboard) UnmarshalJSON(data []byte) (err error) {
+	type templateDashboard TemplateDashboard
+	legacy := struct {
+		*templateDashboard
+		Widgets Widgets `json:"widgets"`
+	}{templateDashboard: (*templateDashboard)(d)}
+	if err = unmarshalFlexible(data, &legacy); err != nil {
+		return
+	}
+	if legacy.Widgets != nil {
+		d.Pages = DashboardPages{{Widgets: legacy.Widgets}}
+	}
+	return
+}
+
+// dashboardParams returns the parameters of dashboard and template dashboard create and update methods for the server version.
+// Before 5.4 the widgets of the single page are given to the dashboard.
+func (api *API) dashboardParams(dashboards interface{}) (params interface{}, err error) {
+	if params, err = api.marshalFor(dashboards, dashboardFields); err != nil || !api.legacy(FeatureDashboardPages) {
+		return
+	}
+	for _, o := range params.([]interface{}) {
+		object := o.(map[string]interface{})
+		pages, _ := object["pages"].([]interface{})
+		if len(pages) > 1 {
+			return nil, &UnsupportedFeature{FeatureDashboardPages, api.ServerVersion}
+		}
+		delete(object, "pages")
+		if len(pages) == 1 {
+			if page, ok := pages[0].(map[string]interface{}); ok && page["widgets"] != nil {
+				object["widgets"] = page["widgets"]
+			}
+		}
+	}
+	return
+}
+
+// selectPages adds to params the selection of the pages and widgets of dashboards.
+func (api *API) selectPages(params Params) Params {
+	if api.Supports(FeatureDashboardPages) {
+		params["selectPages"] = "extend"
+	} else {
+		params["selectWidgets"] = "extend"
+	}
+	return params
+}
+
+// NewIntWidgetField Builds an integer widget field.
+func NewIntWidgetField(name string, value int) WidgetField {
+	return WidgetField{Type: FieldInteger, Name: name, Value: strconv.Itoa(value)}
+}
+
+// NewStringWidgetField Builds a string widget field.
+func NewStringWidgetField(name, value string) WidgetField {
+	return WidgetField{Type: FieldString, Name: name, Value: value}
+}
+
+// NewWidgetReference Builds a widget field referencing a single object, such as the item of an item value widget.
+// The field is named "name.0" from 7.0.
+func (api *API) NewWidgetReference(fieldType WidgetFieldType, name string, id string) WidgetField {
+	if api.Supports(FeatureWideDashboards) {
+		name += ".0"
+	}
+	return WidgetField{Type: fieldType, Name: name, Value: id}
+}
+
+// NewWidgetReferences Builds the fields of a widget referencing several objects, such as the host groups of a problems widget.
+// The fields are named "name.0", "name.1"... from 6.4, and all "name" before.
+func (api *API) NewWidgetReferences(fieldType WidgetFieldType, name string, ids ...string) (res WidgetFields) {
+	for i, id := range ids {
+		res = append(res, WidgetField{Type: fieldType, Name: api.widgetListName(name, i), Value: id})
+	}
+	return
+}
+
+// NewIntWidgetFields Builds the fields of a widget holding several integers, such as the severities of a problems widget.
+// They are named as in NewWidgetReferences.
+func (api *API) NewIntWidgetFields(name string, values ...int) (res WidgetFields) {
+	for i, v := range values {
+		res = append(res, NewIntWidgetField(api.widgetListName(name, i), v))
+	}
+	return
+}
+
+// WidgetFieldName Returns the name of a property of the element of a widget list field at index,
+// such as "columns.0.name" from 6.4 and "columns.name.0" before for the name of the first column of a top hosts widget.
+func (api *API) WidgetFieldName(list string, index int, property string) string {
+	if api.Supports(FeatureIndexedWidgetFields) {
+		return fmt.Sprintf("%s.%d.%s", list, index, property)
+	}
+	return fmt.Sprintf("%s.%s.%d", list, property, index)
+}
+
+func (api *API) widgetListName(name string, index int) string {
+	if api.Supports(FeatureIndexedWidgetFields) {
+		return fmt.Sprintf("%s.%d", name, index)
+	}
+	return name
+}
+
+// NewGraphWidget Builds a classic graph widget showing a graph.
+func (api *API) NewGraphWidget(name string, graphID string) Widget {
+	return Widget{Type: "graph", Name: name, Fields: WidgetFields{
+		NewIntWidgetField("source_type", 0),
+		api.NewWidgetReference(FieldGraph, "graphid", graphID),
+	}}
+}
+
+// NewSimpleGraphWidget Builds a classic graph widget showing the simple graph of an item.
+func (api *API) NewSimpleGraphWidget(name string, itemID string) Widget {
+	return Widget{Type: "graph", Name: name, Fields: WidgetFields{
+		NewIntWidgetField("source_type", 1),
+		api.NewWidgetReference(FieldItem, "itemid", itemID),
+	}}
+}
+
+// NewProblemsWidget Builds a problems widget showing the problems of the host groups, all by default,
+// with the given severities, all by default.
+func (api *API) NewProblemsWidget(name string, groupIDs []string, severities ...SeverityType) Widget {
+	values := make([]int, len(severities))
+	for i, s := range severities {
+		values[i] = int(s)
+	}
+	fields := api.NewWidgetReferences(FieldHostGroup, "groupids", groupIDs...)
+	return Widget{Type: "problems", Name: name, Fields: append(fields, api.NewIntWidgetFields("severities", values...)...)}
+}
+
+// NewItemValueWidget Builds an item value widget showing the last value of an item.
+// NOTE: new in 6.0
+func (api *API) NewItemValueWidget(name string, itemID string) (w Widget, err error) {
+	if err = api.requires(FeatureItemValueWidgets); err != nil {
+		return
+	}
+	w = Widget{Type: "item", Name: name, Fields: WidgetFields{api.NewWidgetReference(FieldItem, "itemid", itemID)}}
+	return
+}
+
+// NewHostAvailabilityWidget Builds a host availability widget counting the hosts of the host groups, all by default.
+func (api *API) NewHostAvailabilityWidget(name string, groupIDs []string) Widget {
+	return Widget{Type: "hostavail", Name: name, Fields: api.NewWidgetReferences(FieldHostGroup, "groupids", groupIDs...)}
+}
+
+// NewPlainTextWidget Builds a plain text widget showing the last lines of values of the items.
+func (api *API) NewPlainTextWidget(name string, itemIDs []string, lines int) Widget {
+	w := Widget{Type: "plaintext", Name: name, Fields: api.NewWidgetReferences(FieldItem, "itemids", itemIDs...)}
+	if lines > 0 {
+		w.Fields = append(w.Fields, NewIntWidgetField("show_lines", lines))
+	}
+	return w
+}
+
+// TopHostsColumn is a column of a top hosts widget
+type TopHostsColumn struct {
+	Name string
+	Data TopHostsColumnData
+	Item string // name of the item, for ColumnItemValue
+	Text string // for ColumnText
+}
+
+// TopHostsColumns is an array of TopHostsColumn
+type TopHostsColumns []TopHostsColumn
+
+// NewTopHostsWidget Builds a top hosts widget listing the given number of hosts of the host groups, all by default,
+// ordered by their first item value column.
+// NOTE: new in 6.0
+func (api *API) NewTopHostsWidget(name string, groupIDs []string, count int, columns TopHostsColumns) (w Widget, err error) {
+	if err = api.requires(FeatureItemValueWidgets); err != nil {
+		return
+	}
+	w = Widget{Type: "tophosts", Name: name, Fields: api.NewWidgetReferences(FieldHostGroup, "groupids", groupIDs...)}
+	if count > 0 {
+		w.Fields = append(w.Fields, NewIntWidgetField("count", count))
+	}
+	order := -1
+	for i, c := range columns {
+		w.Fields = append(w.Fields,
+			NewStringWidgetField(api.WidgetFieldName("columns", i, "name"), c.Name),
+			NewIntWidgetField(api.WidgetFieldName("columns", i, "data"), int(c.Data)),
+		)
+		switch c.Data {
+		case ColumnItemValue:
+			w.Fields = append(w.Fields, NewStringWidgetField(api.WidgetFieldName("columns", i, "item"), c.Item))
+			if order < 0 {
+				order = i
+			}
+		case ColumnText:
+			w.Fields = append(w.Fields, NewStringWidgetField(api.WidgetFieldName("columns", i, "text"), c.Text))
+		}
+	}
+	if order >= 0 {
+		w.Fields = append(w.Fields, NewIntWidgetField("column", order))
+	}
+	return
+}
+
+// ArrangeWidgets Places the widgets in rows of perRow widgets of the same size, each widget being rows of 70 pixels high.
+// Dashboards have 24 columns, and 72 columns with rows of half the height from 7.0.
+func (api *API) ArrangeWidgets(widgets Widgets, perRow, rows int) {
+	columns, scale := 24, 1
+	if api.Supports(FeatureWideDashboards) {
+		columns, scale = 72, 2
+	}
+	if perRow < 1 {
+		perRow = 1
+	} else if perRow > columns {
+		perRow = columns
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	width := columns / perRow
+	for i := range widgets {
+		widgets[i].X = Int(i % perRow * width)
+		widgets[i].Y = Int(i / perRow * rows * scale)
+		widgets[i].Width = Int(width)
+		widgets[i].Height = Int(rows * scale)
+	}
+}
+
+// DashboardsGet Wrapper for dashboard.get
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/get
+func (api *API) DashboardsGet(params Params) (res Dashboards, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("dashboard.get", params, &res)
+	return
+}
+
+// DashboardGetByID Gets dashboard by Id only if there is exactly 1 matching dashboard.
+// Its pages, widgets, users and user groups are selected.
+func (api *API) DashboardGetByID(id string) (res *Dashboard, err error) {
+	dashboards, err := api.DashboardsGet(api.selectPages(Params{
+		"dashboardids":     id,
+		"selectUsers":      "extend",
+		"selectUserGroups": "extend",
+	}))
+	if err != nil {
+		return
+	}
+
+	if len(dashboards) == 1 {
+		res = &dashboards[0]
+	} else {
+		e := ExpectedOneResult(len(dashboards))
+		err = &e
+	}
+	return
+}
+
+// DashboardsCreate Wrapper for dashboard.create
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/create
+func (api *API) DashboardsCreate(dashboards Dashboards) (err error) {
+	params, err := api.dashboardParams(dashboards)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("dashboard.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "dashboardids") {
+		dashboards[i].DashboardID = id
+	}
+	return
+}
+
+// DashboardsUpdate Wrapper for dashboard.update
+// Pages given replace the pages of the dashboard, widgets without ID being created.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/update
+func (api *API) DashboardsUpdate(dashboards Dashboards) (err error) {
+	params, err := api.dashboardParams(dashboards)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("dashboard.update", params)
+	return
+}
+
+// DashboardsDelete Wrapper for dashboard.delete
+// Cleans DashboardID in all dashboards elements if call succeed.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/delete
+func (api *API) DashboardsDelete(dashboards Dashboards) (err error) {
+	ids := make([]string, len(dashboards))
+	for i, dashboard := range dashboards {
+		ids[i] = dashboard.DashboardID
+	}
+
+	err = api.DashboardsDeleteByIds(ids)
+	if err == nil {
+		for i := range dashboards {
+			dashboards[i].DashboardID = ""
+		}
+	}
+	return
+}
+
+// DashboardsDeleteByIds Wrapper for dashboard.delete
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/dashboard/delete
+func (api *API) DashboardsDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("dashboard.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "dashboardids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// TemplateDashboardsGet Wrapper for templatedashboard.get
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/templatedashboard/get
+func (api *API) TemplateDashboardsGet(params Params) (res TemplateDashboards, err error) {
+	if err = api.requires(FeatureTemplateDashboards); err != nil {
+		return
+	}
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("templatedashboard.get", params, &res)
+	return
+}
+
+// TemplateDashboardGetByID Gets template dashboard by Id only if there is exactly 1 matching template dashboard.
+// Its pages and widgets are selected.
+func (api *API) TemplateDashboardGetByID(id string) (res *TemplateDashboard, err error) {
+	dashboards, err := api.TemplateDashboardsGet(api.selectPages(Params{"dashboardids": id}))
+	if err != nil {
+		return
+	}
+
+	if len(dashboards) == 1 {
+		res = &dashboards[0]
+	} else {
+		e := ExpectedOneResult(len(dashboards))
+		err = &e
+	}
+	return
+}
+
+// TemplateDashboardsCreate Wrapper for templatedashboard.create
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/templatedashboard/create
+func (api *API) TemplateDashboardsCreate(dashboards TemplateDashboards) (err error) {
+	if err = api.requires(FeatureTemplateDashboards); err != nil {
+		return
+	}
+	params, err := api.dashboardParams(dashboards)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("templatedashboard.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "dashboardids") {
+		dashboards[i].DashboardID = id
+	}
+	return
+}
+
+// TemplateDashboardsUpdate Wrapper for templatedashboard.update
+// The template of a dashboard cannot be changed, TemplateID must be empty.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/templatedashboard/update
+func (api *API) TemplateDashboardsUpdate(dashboards TemplateDashboards) (err error) {
+	if err = api.requires(FeatureTemplateDashboards); err != nil {
+		return
+	}
+	params, err := api.dashboardParams(dashboards)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("templatedashboard.update", params)
+	return
+}
+
+// TemplateDashboardsDelete Wrapper for templatedashboard.delete
+// Cleans DashboardID in all template dashboards elements if call succeed.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/templatedashboard/delete
+func (api *API) TemplateDashboardsDelete(dashboards TemplateDashboards) (err error) {
+	ids := make([]string, len(dashboards))
+	for i, dashboard := range dashboards {
+		ids[i] = dashboard.DashboardID
+	}
+
+	err = api.TemplateDashboardsDeleteByIds(ids)
+	if err == nil {
+		for i := range dashboards {
+			dashboards[i].DashboardID = ""
+		}
+	}
+	return
+}
+
+// TemplateDashboardsDeleteByIds Wrapper for templatedashboard.delete
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/templatedashboard/delete
+func (api *API) TemplateDashboardsDeleteByIds(ids []string) (err error) {
+	if err = api.requires(FeatureTemplateDashboards); err != nil {
+		return
+	}
+	response, err := api.CallWithError("templatedashboard.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "dashboardids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
@@ -0,0 +1,175 @@
+package zabbix_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestWidgetFieldNames(t *testing.T) {
+	api := testGetAPI(t)
+
+	fields := api.NewWidgetReferences(zapi.FieldHostGroup, "groupids", "1", "2")
+	name, column := "groupids", "columns.name.1"
+	if api.Supports(zapi.FeatureIndexedWidgetFields) {
+		name, column = "groupids.1", "columns.1.name"
+	}
+	if len(fields) != 2 || fields[1].Name != name || fields[1].Value != "2" || fields[1].Type != zapi.FieldHostGroup {
+		t.Errorf("Unexpected fields: %#v", fields)
+	}
+	if c := api.WidgetFieldName("columns", 1, "name"); c != column {
+		t.Errorf("Column field is named %q instead of %q", c, column)
+	}
+
+	widgets := zapi.Widgets{api.NewGraphWidget("graph", "1"), api.NewPlainTextWidget("text", []string{"1"}, 5), api.NewHostAvailabilityWidget("hosts", nil)}
+	api.ArrangeWidgets(widgets, 2, 4)
+	columns, height := 24, 4
+	if api.Supports(zapi.FeatureWideDashboards) {
+		columns, height = 72, 8
+	}
+	if widgets[1].X != zapi.Int(columns/2) || widgets[1].Y != 0 || widgets[2].X != 0 || widgets[2].Y != zapi.Int(height) || widgets[2].Width != zapi.Int(columns/2) {
+		t.Errorf("Unexpected layout: %#v", widgets)
+	}
+}
+
+func TestDashboards(t *testing.T) {
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	item := testCreateItem(host, t)
+	defer testDeleteItem(item, t)
+
+	widgets := zapi.Widgets{
+		api.NewProblemsWidget("Problems", []string{group.GroupID}, zapi.High, zapi.Critical),
+		api.NewHostAvailabilityWidget("Availability", []string{group.GroupID}),
+		api.NewPlainTextWidget("Values", []string{item.ItemID}, 10),
+		api.NewSimpleGraphWidget("Graph", item.ItemID),
+	}
+	if api.Supports(zapi.FeatureItemValueWidgets) {
+		value, err := api.NewItemValueWidget("Value", item.ItemID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		top, err := api.NewTopHostsWidget("Top hosts", []string{group.GroupID}, 5, zapi.TopHostsColumns{
+			{Name: "Host", Data: zapi.ColumnHostName},
+			{Name: "Value", Data: zapi.ColumnItemValue, Item: item.Name},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		widgets = append(widgets, value, top)
+	} else if _, err := api.NewItemValueWidget("Value", item.ItemID); err == nil {
+		t.Errorf("Expected an UnsupportedFeature error on Zabbix %s", api.ServerVersion)
+	}
+	api.ArrangeWidgets(widgets, 2, 4)
+
+	dashboards := zapi.Dashboards{{
+		Name:    fmt.Sprintf("zabbix-testing-%d", rand.Int()),
+		Private: 1,
+		Pages:   zapi.DashboardPages{{Widgets: widgets}},
+	}}
+	err := api.DashboardsCreate(dashboards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dashboard := &dashboards[0]
+	if dashboard.DashboardID == "" {
+		t.Errorf("Dashboard ID is empty: %#v", dashboard)
+	}
+
+	dashboard2, err := api.DashboardGetByID(dashboard.DashboardID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dashboard2.Name != dashboard.Name || len(dashboard2.Pages) != 1 || len(dashboard2.Pages[0].Widgets) != len(widgets) {
+		t.Fatalf("Dashboards are not equal:\n%#v\n%#v", dashboard, dashboard2)
+	}
+	for i, w := range dashboard2.Pages[0].Widgets {
+		if w.WidgetID == "" || w.Type != widgets[i].Type || w.X != widgets[i].X || w.Y != widgets[i].Y {
+			t.Errorf("Unexpected widget %d: %#v", i, w)
+		}
+	}
+
+	dashboard2.Name += "-updated"
+	if api.Supports(zapi.FeatureDashboardPages) {
+		dashboard2.Pages = append(dashboard2.Pages, zapi.DashboardPage{Name: "Second"})
+	}
+	err = api.DashboardsUpdate(zapi.Dashboards{*dashboard2})
+	if err != nil {
+		t.Error(err)
+	}
+	dashboard3, err := api.DashboardGetByID(dashboard.DashboardID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dashboard3.Name != dashboard2.Name || len(dashboard3.Pages) != len(dashboard2.Pages) {
+		t.Errorf("Dashboard not updated: %#v", dashboard3)
+	}
+
+	err = api.DashboardsDelete(dashboards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dashboard.DashboardID != "" {
+		t.Errorf("Dashboard ID is not cleaned: %#v", dashboard)
+	}
+}
+
+func TestTemplateDashboards(t *testing.T) {
+	skipTestIfVersionLessThan(t, "5.2", "Template dashboards are new in 5.2")
+	api := testGetAPI(t)
+
+	// Zabbix v6.2 introduced Template Groups and requires them for Templates
+	var groupIds zapi.HostGroupIDs
+	if compLessThan, _ := isVersionLessThan(t, "6.2"); compLessThan {
+		hostGroup := testCreateHostGroup(t)
+		defer testDeleteHostGroup(hostGroup, t)
+		groupIds = zapi.HostGroupIDs{{GroupID: hostGroup.GroupID}}
+	} else {
+		templateGroup := testCreateTemplateGroup(t)
+		defer testDeleteTemplateGroup(templateGroup, t)
+		groupIds = zapi.HostGroupIDs{{GroupID: templateGroup.GroupID}}
+	}
+
+	template := testCreateTemplate(&groupIds, t)
+	defer testDeleteTemplate(template, t)
+
+	dashboards := zapi.TemplateDashboards{{
+		TemplateID: template.TemplateID,
+		Name:       "Overview",
+		Pages:      zapi.DashboardPages{{Widgets: zapi.Widgets{api.NewPlainTextWidget("Values", nil, 0)}}},
+	}}
+	api.ArrangeWidgets(dashboards[0].Pages[0].Widgets, 1, 5)
+	err := api.TemplateDashboardsCreate(dashboards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dashboard := &dashboards[0]
+
+	dashboard.TemplateID = ""
+	dashboard.Name = "Overview updated"
+	err = api.TemplateDashboardsUpdate(dashboards)
+	if err != nil {
+		t.Error(err)
+	}
+
+	dashboard2, err := api.TemplateDashboardGetByID(dashboard.DashboardID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dashboard2.Name != dashboard.Name || dashboard2.TemplateID != template.TemplateID || len(dashboard2.Pages) != 1 || len(dashboard2.Pages[0].Widgets) != 1 {
+		t.Errorf("Unexpected template dashboard: %#v", dashboard2)
+	}
+
+	err = api.TemplateDashboardsDelete(dashboards)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
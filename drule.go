@@ -0,0 +1,655 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type (
+	// DiscoveryCheckType Type of a network discovery check
+	// see "type" in https://www.zabbix.com/documentation/5.0/manual/api/reference/dcheck/object
+	DiscoveryCheckType int
+
+	// DiscoveryNameSource Source of the host name or visible name of discovered hosts
+	// see "host_source" and "name_source" in https://www.zabbix.com/documentation/5.0/manual/api/reference/dcheck/object
+	DiscoveryNameSource int
+
+	// DiscoveryStatus Status of a discovered host or service
+	// see "status" in https://www.zabbix.com/documentation/5.0/manual/api/reference/dhost/object
+	DiscoveryStatus int
+
+	// SNMPv3SecurityLevel Security level of SNMPv3 requests
+	// see "snmpv3_securitylevel" in https://www.zabbix.com/documentation/5.0/manual/api/reference/dcheck/object
+	SNMPv3SecurityLevel int
+
+	// SNMPv3AuthProtocol Authentication protocol of SNMPv3 requests
+	// see "snmpv3_authprotocol" in https://www.zabbix.com/documentation/5.0/manual/api/reference/dcheck/object
+	SNMPv3AuthProtocol int
+
+	// SNMPv3PrivProtocol Privacy protocol of SNMPv3 requests
+	// see "snmpv3_privprotocol" in https://www.zabbix.com/documentation/5.0/manual/api/reference/dcheck/object
+	SNMPv3PrivProtocol int
+)
+
+const (
+	// CheckSSH SSH service
+	CheckSSH DiscoveryCheckType = 0
+	// CheckLDAP LDAP service
+	CheckLDAP DiscoveryCheckType = 1
+	// CheckSMTP SMTP service
+	CheckSMTP DiscoveryCheckType = 2
+	// CheckFTP FTP service
+	CheckFTP DiscoveryCheckType = 3
+	// CheckHTTP HTTP service
+	CheckHTTP DiscoveryCheckType = 4
+	// CheckPOP POP service
+	CheckPOP DiscoveryCheckType = 5
+	// CheckNNTP NNTP service
+	CheckNNTP DiscoveryCheckType = 6
+	// CheckIMAP IMAP service
+	CheckIMAP DiscoveryCheckType = 7
+	// CheckTCP TCP port open
+	CheckTCP DiscoveryCheckType = 8
+	// CheckAgent Zabbix agent item given by Key
+	CheckAgent DiscoveryCheckType = 9
+	// CheckSNMPv1 SNMPv1 agent OID given by Key
+	CheckSNMPv1 DiscoveryCheckType = 10
+	// CheckSNMPv2c SNMPv2c agent OID given by Key
+	CheckSNMPv2c DiscoveryCheckType = 11
+	// CheckICMP ICMP ping
+	CheckICMP DiscoveryCheckType = 12
+	// CheckSNMPv3 SNMPv3 agent OID given by Key
+	CheckSNMPv3 DiscoveryCheckType = 13
+	// CheckHTTPS HTTPS service
+	CheckHTTPS DiscoveryCheckType = 14
+	// CheckTelnet Telnet service
+	CheckTelnet DiscoveryCheckType = 15
+)
+
+const (
+	// NameDefault (default of name_source) visible name not specified, the host name is used
+	NameDefault DiscoveryNameSource = 0
+	// NameFromDNS (default of host_source) DNS name
+	NameFromDNS DiscoveryNameSource = 1
+	// NameFromIP IP address
+	NameFromIP DiscoveryNameSource = 2
+	// NameFromCheck value of the check
+	NameFromCheck DiscoveryNameSource = 3
+)
+
+const (
+	// DiscoveryUp host or service up
+	DiscoveryUp DiscoveryStatus = 0
+	// DiscoveryDown host or service down
+	DiscoveryDown DiscoveryStatus = 1
+)
+
+const (
+	// SecurityNoAuthNoPriv (default) no authentication nor privacy
+	SecurityNoAuthNoPriv SNMPv3SecurityLevel = 0
+	// SecurityAuthNoPriv authentication without privacy
+	SecurityAuthNoPriv SNMPv3SecurityLevel = 1
+	// SecurityAuthPriv authentication and privacy
+	SecurityAuthPriv SNMPv3SecurityLevel = 2
+)
+
+const (
+	// AuthMD5 (default) MD5
+	AuthMD5 SNMPv3AuthProtocol = 0
+	// AuthSHA1 SHA1
+	AuthSHA1 SNMPv3AuthProtocol = 1
+	// AuthSHA224 SHA224, new in 5.0
+	AuthSHA224 SNMPv3AuthProtocol = 2
+	// AuthSHA256 SHA256, new in 5.0
+	AuthSHA256 SNMPv3AuthProtocol = 3
+	// AuthSHA384 SHA384, new in 5.0
+	AuthSHA384 SNMPv3AuthProtocol = 4
+	// AuthSHA512 SHA512, new in 5.0
+	AuthSHA512 SNMPv3AuthProtocol = 5
+)
+
+const (
+	// PrivDES (default) DES
+	PrivDES SNMPv3PrivProtocol = 0
+	// PrivAES128 AES128
+	PrivAES128 SNMPv3PrivProtocol = 1
+	// PrivAES192 AES192, new in 5.0
+	PrivAES192 SNMPv3PrivProtocol = 2
+	// PrivAES256 AES256, new in 5.0
+	PrivAES256 SNMPv3PrivProtocol = 3
+	// PrivAES192C AES192 with Cisco key extension, new in 5.0
+	PrivAES192C SNMPv3PrivProtocol = 4
+	// PrivAES256C AES256 with Cisco key extension, new in 5.0
+	PrivAES256C SNMPv3PrivProtocol = 5
+)
+
+// MarshalJSON encodes t as a string.
+func (t DiscoveryCheckType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *DiscoveryCheckType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var discoveryCheckTypeNames = enumNames{
+	kind: "discovery check type",
+	names: map[int][]string{
+		int(CheckSSH):     {"SSH"},
+		int(CheckLDAP):    {"LDAP"},
+		int(CheckSMTP):    {"SMTP"},
+		int(CheckFTP):     {"FTP"},
+		int(CheckHTTP):    {"HTTP"},
+		int(CheckPOP):     {"POP"},
+		int(CheckNNTP):    {"NNTP"},
+		int(CheckIMAP):    {"IMAP"},
+		int(CheckTCP):     {"TCP"},
+		int(CheckAgent):   {"Zabbix agent"},
+		int(CheckSNMPv1):  {"SNMPv1 agent"},
+		int(CheckSNMPv2c): {"SNMPv2 agent"},
+		int(CheckICMP):    {"ICMP ping"},
+		int(CheckSNMPv3):  {"SNMPv3 agent"},
+		int(CheckHTTPS):   {"HTTPS"},
+		int(CheckTelnet):  {"Telnet"},
+	},
+}
+
+func (t DiscoveryCheckType) String() string {
+	return discoveryCheckTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t DiscoveryCheckType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *DiscoveryCheckType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseDiscoveryCheckType(string(text))
+	return
+}
+
+// ParseDiscoveryCheckType Parses a discovery check type from its display name or number.
+func ParseDiscoveryCheckType(s string) (DiscoveryCheckType, error) {
+	v, err := discoveryCheckTypeNames.parse(s)
+	return DiscoveryCheckType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t DiscoveryNameSource) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *DiscoveryNameSource) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var discoveryNameSourceNames = enumNames{
+	kind: "name source",
+	names: map[int][]string{
+		int(NameDefault):   {"Not specified", "Default"},
+		int(NameFromDNS):   {"DNS name"},
+		int(NameFromIP):    {"IP address"},
+		int(NameFromCheck): {"Check value"},
+	},
+}
+
+func (t DiscoveryNameSource) String() string {
+	return discoveryNameSourceNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t DiscoveryNameSource) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *DiscoveryNameSource) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseDiscoveryNameSource(string(text))
+	return
+}
+
+// ParseDiscoveryNameSource Parses a name source from its display name or number.
+func ParseDiscoveryNameSource(s string) (DiscoveryNameSource, error) {
+	v, err := discoveryNameSourceNames.parse(s)
+	return DiscoveryNameSource(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t DiscoveryStatus) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *DiscoveryStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var discoveryStatusNames = enumNames{
+	kind: "discovery status",
+	names: map[int][]string{
+		int(DiscoveryUp):   {"Up"},
+		int(DiscoveryDown): {"Down"},
+	},
+}
+
+func (t DiscoveryStatus) String() string {
+	return discoveryStatusNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t DiscoveryStatus) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *DiscoveryStatus) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseDiscoveryStatus(string(text))
+	return
+}
+
+// ParseDiscoveryStatus Parses a discovery status from its display name or number.
+func ParseDiscoveryStatus(s string) (DiscoveryStatus, error) {
+	v, err := discoveryStatusNames.parse(s)
+	return DiscoveryStatus(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t SNMPv3SecurityLevel) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *SNMPv3SecurityLevel) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var snmpv3SecurityLevelNames = enumNames{
+	kind: "security level",
+	names: map[int][]string{
+		int(SecurityNoAuthNoPriv): {"noAuthNoPriv"},
+		int(SecurityAuthNoPriv):   {"authNoPriv"},
+		int(SecurityAuthPriv):     {"authPriv"},
+	},
+}
+
+func (t SNMPv3SecurityLevel) String() string {
+	return snmpv3SecurityLevelNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t SNMPv3SecurityLevel) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *SNMPv3SecurityLevel) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseSNMPv3SecurityLevel(string(text))
+	return
+}
+
+// ParseSNMPv3SecurityLevel Parses a security level from its display name or number.
+func ParseSNMPv3SecurityLevel(s string) (SNMPv3SecurityLevel, error) {
+	v, err := snmpv3SecurityLevelNames.parse(s)
+	return SNMPv3SecurityLevel(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t SNMPv3AuthProtocol) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *SNMPv3AuthProtocol) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var snmpv3AuthProtocolNames = enumNames{
+	kind: "authentication protocol",
+	names: map[int][]string{
+		int(AuthMD5):    {"MD5"},
+		int(AuthSHA1):   {"SHA1", "SHA"},
+		int(AuthSHA224): {"SHA224"},
+		int(AuthSHA256): {"SHA256"},
+		int(AuthSHA384): {"SHA384"},
+		int(AuthSHA512): {"SHA512"},
+	},
+}
+
+func (t SNMPv3AuthProtocol) String() string {
+	return snmpv3AuthProtocolNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t SNMPv3AuthProtocol) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *SNMPv3AuthProtocol) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseSNMPv3AuthProtocol(string(text))
+	return
+}
+
+// ParseSNMPv3AuthProtocol Parses an authentication protocol from its display name or number.
+func ParseSNMPv3AuthProtocol(s string) (SNMPv3AuthProtocol, error) {
+	v, err := snmpv3AuthProtocolNames.parse(s)
+	return SNMPv3AuthProtocol(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t SNMPv3PrivProtocol) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *SNMPv3PrivProtocol) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var snmpv3PrivProtocolNames = enumNames{
+	kind: "privacy protocol",
+	names: map[int][]string{
+		int(PrivDES):     {"DES"},
+		int(PrivAES128):  {"AES128", "AES"},
+		int(PrivAES192):  {"AES192"},
+		int(PrivAES256):  {"AES256"},
+		int(PrivAES192C): {"AES192C"},
+		int(PrivAES256C): {"AES256C"},
+	},
+}
+
+func (t SNMPv3PrivProtocol) String() string {
+	return snmpv3PrivProtocolNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t SNMPv3PrivProtocol) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *SNMPv3PrivProtocol) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseSNMPv3PrivProtocol(string(text))
+	return
+}
+
+// ParseSNMPv3PrivProtocol Parses a privacy protocol from its display name or number.
+func ParseSNMPv3PrivProtocol(s string) (SNMPv3PrivProtocol, error) {
+	v, err := snmpv3PrivProtocolNames.parse(s)
+	return SNMPv3PrivProtocol(v), err
+}
+
+// IPRanges are the addresses scanned by a discovery rule, each being an address, a range such as "192.168.1.1-255"
+// or a network such as "192.168.4.0/24", exchanged as a comma separated list.
+type IPRanges []string
+
+// MarshalJSON encodes r as a comma separated list.
+func (r IPRanges) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.Join(r, ","))
+}
+
+// UnmarshalJSON decodes a comma separated list.
+func (r *IPRanges) UnmarshalJSON(data []byte) error {
+	s, err := scalarString(data)
+	if err != nil {
+		return err
+	}
+	*r = nil
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			*r = append(*r, part)
+		}
+	}
+	return nil
+}
+
+// DiscoveryCheck represent Zabbix discovery check object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/dcheck/object
+type DiscoveryCheck struct {
+	CheckID string             `json:"dcheckid,omitempty"` // Readonly
+	RuleID  string             `json:"druleid,omitempty"`  // Readonly
+	Type    DiscoveryCheckType `json:"type"`               // Required
+	Ports   string             `json:"ports,omitempty"`    // such as "21,8080-8090", the usual port of the service by default
+	Key     string             `json:"key_,omitempty"`     // item key of agent checks, OID of SNMP checks
+
+	// Unique 1 to identify devices by the value of the check instead of their IP address, for one check of the rule at most.
+	Unique     Int                 `json:"uniq,omitempty"`
+	HostSource DiscoveryNameSource `json:"host_source,omitempty"`
+	NameSource DiscoveryNameSource `json:"name_source,omitempty"`
+
+	// SNMPv1 and SNMPv2c checks
+	SNMPCommunity string `json:"snmp_community,omitempty"`
+
+	// SNMPv3 checks
+	SNMPv3SecurityName  string              `json:"snmpv3_securityname,omitempty"`
+	SNMPv3SecurityLevel SNMPv3SecurityLevel `json:"snmpv3_securitylevel,omitempty"`
+	SNMPv3AuthProtocol  SNMPv3AuthProtocol  `json:"snmpv3_authprotocol,omitempty"`
+	SNMPv3AuthPass      string              `json:"snmpv3_authpassphrase,omitempty"`
+	SNMPv3PrivProtocol  SNMPv3PrivProtocol  `json:"snmpv3_privprotocol,omitempty"`
+	SNMPv3PrivPass      string              `json:"snmpv3_privpassphrase,omitempty"`
+	SNMPv3ContextName   string              `json:"snmpv3_contextname,omitempty"`
+}
+
+// DiscoveryChecks is an array of DiscoveryCheck
+type DiscoveryChecks []DiscoveryCheck
+
+// MarshalJSON omits the read-only rule, so that fetched checks can be given back to drule.update.
+func (c DiscoveryCheck) MarshalJSON() ([]byte, error) {
+	type discoveryCheck DiscoveryCheck
+	c.RuleID = ""
+	return json.Marshal(discoveryCheck(c))
+}
+
+// NetworkDiscoveryRule represent Zabbix network discovery rule object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/drule/object
+type NetworkDiscoveryRule struct {
+	RuleID   string     `json:"druleid,omitempty"` // Readonly
+	Name     string     `json:"name"`              // Required
+	IPRanges IPRanges   `json:"iprange"`           // Required
+	Delay    string     `json:"delay,omitempty"`   // such as "1h"
+	Status   StatusType `json:"status"`
+
+	// Proxy running the rule, empty for the server, always sent so that the rule can move back to the server.
+	// NOTE: proxy_hostid before Zabbix 7.0
+	ProxyID string `json:"proxyid,omitempty"`
+
+	// Checks are required on creation and returned with selectDChecks.
+	Checks DiscoveryChecks `json:"dchecks,omitempty"`
+}
+
+// NetworkDiscoveryRules is an array of NetworkDiscoveryRule
+type NetworkDiscoveryRules []NetworkDiscoveryRule
+
+var networkDiscoveryRuleFields = fieldRules{
+	{Field: "proxyid", Feature: FeatureProxyFields, Legacy: "proxy_hostid"},
+}
+
+// UnmarshalJSON decodes the proxy of the rule whatever the server version, "0" meaning none.
+func (r *NetworkDiscoveryRule) UnmarshalJSON(data []byte) (err error) {
+	if data, err = networkDiscoveryRuleFields.canonical(data); err != nil {
+		return
+	}
+	type networkDiscoveryRule NetworkDiscoveryRule
+	if err = unmarshalFlexible(data, (*networkDiscoveryRule)(r)); err != nil {
+		return
+	}
+	if r.ProxyID == "0" {
+		r.ProxyID = ""
+	}
+	return
+}
+
+// networkDiscoveryRuleParams returns the parameters of drule.create and drule.update for the server version,
+// an empty proxy being sent as "0".
+func (api *API) networkDiscoveryRuleParams(rules NetworkDiscoveryRules) (params interface{}, err error) {
+	if params, err = api.marshalFor(rules, networkDiscoveryRuleFields); err != nil {
+		return
+	}
+	key := "proxyid"
+	if api.legacy(FeatureProxyFields) {
+		key = "proxy_hostid"
+	}
+	for _, o := range params.([]interface{}) {
+		object := o.(map[string]interface{})
+		if _, present := object[key]; !present {
+			object[key] = "0"
+		}
+	}
+	return
+}
+
+// DiscoveredService represent Zabbix discovered service object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/dservice/object
+type DiscoveredService struct {
+	ServiceID string          `json:"dserviceid"`
+	HostID    string          `json:"dhostid"`
+	CheckID   string          `json:"dcheckid"`
+	IP        string          `json:"ip"`
+	DNS       string          `json:"dns"`
+	Port      Int             `json:"port"`
+	Value     string          `json:"value"` // value of agent and SNMP checks
+	Status    DiscoveryStatus `json:"status"`
+	LastUp    Timestamp       `json:"lastup"`
+	LastDown  Timestamp       `json:"lastdown"`
+}
+
+// DiscoveredServices is an array of DiscoveredService
+type DiscoveredServices []DiscoveredService
+
+// DiscoveredHost represent Zabbix discovered host object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/dhost/object
+type DiscoveredHost struct {
+	HostID   string          `json:"dhostid"`
+	RuleID   string          `json:"druleid"`
+	Status   DiscoveryStatus `json:"status"`
+	LastUp   Timestamp       `json:"lastup"`
+	LastDown Timestamp       `json:"lastdown"`
+
+	// Services are returned with selectDServices.
+	Services DiscoveredServices `json:"dservices,omitempty"`
+}
+
+// DiscoveredHosts is an array of DiscoveredHost
+type DiscoveredHosts []DiscoveredHost
+
+// NetworkDiscoveryRulesGet Wrapper for drule.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/drule/get
+func (api *API) NetworkDiscoveryRulesGet(params Params) (res NetworkDiscoveryRules, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("drule.get", params, &res)
+	return
+}
+
+// NetworkDiscoveryRuleGetByID Gets network discovery rule by Id only if there is exactly 1 matching network discovery rule.
+// Its checks are selected.
+func (api *API) NetworkDiscoveryRuleGetByID(id string) (res *NetworkDiscoveryRule, err error) {
+	rules, err := api.NetworkDiscoveryRulesGet(Params{"druleids": id, "selectDChecks": "extend"})
+	if err != nil {
+		return
+	}
+
+	if len(rules) == 1 {
+		res = &rules[0]
+	} else {
+		e := ExpectedOneResult(len(rules))
+		err = &e
+	}
+	return
+}
+
+// NetworkDiscoveryRulesCreate Wrapper for drule.create
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/drule/create
+func (api *API) NetworkDiscoveryRulesCreate(rules NetworkDiscoveryRules) (err error) {
+	params, err := api.networkDiscoveryRuleParams(rules)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("drule.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "druleids") {
+		rules[i].RuleID = id
+	}
+	return
+}
+
+// NetworkDiscoveryRulesUpdate Wrapper for drule.update
+// Checks given replace the checks of the rule.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/drule/update
+func (api *API) NetworkDiscoveryRulesUpdate(rules NetworkDiscoveryRules) (err error) {
+	params, err := api.networkDiscoveryRuleParams(rules)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("drule.update", params)
+	return
+}
+
+// NetworkDiscoveryRulesDelete Wrapper for drule.delete
+// Cleans RuleID in all network discovery rules elements if call succeed.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/drule/delete
+func (api *API) NetworkDiscoveryRulesDelete(rules NetworkDiscoveryRules) (err error) {
+	ids := make([]string, len(rules))
+	for i, rule := range rules {
+		ids[i] = rule.RuleID
+	}
+
+	err = api.NetworkDiscoveryRulesDeleteByIds(ids)
+	if err == nil {
+		for i := range rules {
+			rules[i].RuleID = ""
+		}
+	}
+	return
+}
+
+// NetworkDiscoveryRulesDeleteByIds Wrapper for drule.delete
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/drule/delete
+func (api *API) NetworkDiscoveryRulesDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("drule.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "druleids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// DiscoveryChecksGet Wrapper for dcheck.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/dcheck/get
+func (api *API) DiscoveryChecksGet(params Params) (res DiscoveryChecks, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("dcheck.get", params, &res)
+	return
+}
+
+// DiscoveredHostsGet Wrapper for dhost.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/dhost/get
+func (api *API) DiscoveredHostsGet(params Params) (res DiscoveredHosts, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("dhost.get", params, &res)
+	return
+}
+
+// DiscoveredServicesGet Wrapper for dservice.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/dservice/get
+func (api *API) DiscoveredServicesGet(params Params) (res DiscoveredServices, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("dservice.get", params, &res)
+	return
+}
@@ -0,0 +1,138 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestIPRangesEncoding(t *testing.T) {
+	ranges := zapi.IPRanges{"192.168.1.1-255", "10.0.0.0/24"}
+	b, err := json.Marshal(ranges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"192.168.1.1-255,10.0.0.0/24"` {
+		t.Errorf("Unexpected IP ranges: %s", b)
+	}
+
+	var decoded zapi.IPRanges
+	if err = json.Unmarshal([]byte(`"192.168.1.1-255, 10.0.0.0/24,"`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, ranges) {
+		t.Errorf("Unexpected decoded IP ranges: %#v", decoded)
+	}
+}
+
+func TestNetworkDiscoveryRuleProxyParams(t *testing.T) {
+	for version, key := range map[string]string{"6.0.0": "proxy_hostid", "7.0.0": "proxyid"} {
+		var sent []map[string]interface{}
+		api := testFakeAPI(t, version, func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+			if err := json.Unmarshal(params, &sent); err != nil {
+				t.Fatal(err)
+			}
+			return `{"druleids":["1"]}`, nil
+		})
+		err := api.NetworkDiscoveryRulesUpdate(zapi.NetworkDiscoveryRules{{RuleID: "1", Name: "a", IPRanges: zapi.IPRanges{"192.0.2.1"}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sent[0][key] != "0" || len(sent[0]) != 5 {
+			t.Errorf("Zabbix %s: unexpected parameters %v", version, sent[0])
+		}
+	}
+}
+
+func TestNetworkDiscoveryRules(t *testing.T) {
+	api := testGetAPI(t)
+
+	rules := zapi.NetworkDiscoveryRules{{
+		Name:     fmt.Sprintf("zabbix-testing-%d", rand.Int()),
+		IPRanges: zapi.IPRanges{"192.0.2.1-10"},
+		Delay:    "1h",
+		Status:   zapi.Disabled,
+		Checks: zapi.DiscoveryChecks{
+			{Type: zapi.CheckICMP},
+			{Type: zapi.CheckTCP, Ports: "22", Unique: 1},
+			{Type: zapi.CheckAgent, Ports: "10050", Key: "system.hostname", NameSource: zapi.NameFromCheck},
+		},
+	}}
+	err := api.NetworkDiscoveryRulesCreate(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := &rules[0]
+	if rule.RuleID == "" {
+		t.Errorf("Network discovery rule ID is empty: %#v", rule)
+	}
+
+	rule2, err := api.NetworkDiscoveryRuleGetByID(rule.RuleID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule2.Name != rule.Name || rule2.Status != zapi.Disabled || !reflect.DeepEqual(rule2.IPRanges, rule.IPRanges) || len(rule2.Checks) != 3 {
+		t.Fatalf("Network discovery rules are not equal:\n%#v\n%#v", rule, rule2)
+	}
+
+	checks, err := api.DiscoveryChecksGet(zapi.Params{"druleids": rule.RuleID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unique := 0
+	for _, check := range checks {
+		if check.Unique == 1 {
+			unique++
+			if check.Type != zapi.CheckTCP || check.Ports != "22" {
+				t.Errorf("Unexpected unique check: %#v", check)
+			}
+		}
+	}
+	if len(checks) != 3 || unique != 1 {
+		t.Errorf("Unexpected checks: %#v", checks)
+	}
+
+	hosts, err := api.DiscoveredHostsGet(zapi.Params{"druleids": rule.RuleID, "selectDServices": "extend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 0 {
+		t.Errorf("Disabled rule discovered hosts: %#v", hosts)
+	}
+
+	rule2.IPRanges = append(rule2.IPRanges, "198.51.100.0/30")
+	rule2.Checks = rule2.Checks[:1]
+	err = api.NetworkDiscoveryRulesUpdate(zapi.NetworkDiscoveryRules{*rule2})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// from the server to a proxy and back
+	proxy := testCreateProxy(zapi.ActiveProxy, t)
+	defer testDeleteProxy(proxy, t)
+	for _, proxyID := range []string{proxy.ProxyID, ""} {
+		rule2.ProxyID = proxyID
+		if err = api.NetworkDiscoveryRulesUpdate(zapi.NetworkDiscoveryRules{*rule2}); err != nil {
+			t.Fatal(err)
+		}
+		if rule2, err = api.NetworkDiscoveryRuleGetByID(rule.RuleID); err != nil {
+			t.Fatal(err)
+		}
+		if rule2.ProxyID != proxyID {
+			t.Errorf("Bad rule proxy %q, expected %q", rule2.ProxyID, proxyID)
+		}
+	}
+
+	err = api.NetworkDiscoveryRulesDelete(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.RuleID != "" {
+		t.Errorf("Network discovery rule ID is not cleaned: %#v", rule)
+	}
+}
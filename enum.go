@@ -0,0 +1,38 @@
+package zabbix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// enumNames holds the names of the values of an enumeration, as displayed by the Zabbix frontend.
+// The first name of a value is used to format it, all of them are accepted when parsing.
+type enumNames struct {
+	kind  string
+	names map[int][]string
+}
+
+// format returns the display name of v, or its number if it has none.
+func (e enumNames) format(v int) string {
+	if names, ok := e.names[v]; ok {
+		return names[0]
+	}
+	return strconv.Itoa(v)
+}
+
+// parse accepts any name of a value, case insensitively, or its number.
+func (e enumNames) parse(s string) (int, error) {
+	name := strings.TrimSpace(s)
+	for v, names := range e.names {
+		for _, n := range names {
+			if strings.EqualFold(n, name) {
+				return v, nil
+			}
+		}
+	}
+	if v, err := strconv.Atoi(name); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("zabbix: unknown %s %q", e.kind, s)
+}
@@ -0,0 +1,58 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestEnumNames(t *testing.T) {
+	if s := zapi.ZabbixAgentActive.String(); s != "Zabbix agent (active)" {
+		t.Errorf("Bad item type name: %q", s)
+	}
+	if s := fmt.Sprint(zapi.High); s != "High" {
+		t.Errorf("Bad severity name: %q", s)
+	}
+	if s := zapi.ItemType(42).String(); s != "42" {
+		t.Errorf("Unknown values should print as numbers, got %q", s)
+	}
+
+	itemType, err := zapi.ParseItemType("zabbix agent (active)")
+	if err != nil || itemType != zapi.ZabbixAgentActive {
+		t.Errorf("Bad parsed item type: %v, %v", itemType, err)
+	}
+	severity, err := zapi.ParseSeverityType("4")
+	if err != nil || severity != zapi.High {
+		t.Errorf("Bad parsed severity: %v, %v", severity, err)
+	}
+	if _, err = zapi.ParseValueType("Numeric (double)"); err == nil {
+		t.Error("Expected an error parsing an unknown value type")
+	}
+	source, err := zapi.ParseEventType("Autoregistration")
+	if err != nil || source != zapi.AutoRegistrationEvent {
+		t.Errorf("Bad parsed event source: %v, %v", source, err)
+	}
+
+	var condition zapi.ActionConditionType
+	if err = condition.UnmarshalText([]byte("Host metadata")); err != nil || condition != zapi.HostMetadataCondition {
+		t.Errorf("Bad unmarshaled condition type: %v, %v", condition, err)
+	}
+	text, err := zapi.Unsigned.MarshalText()
+	if err != nil || string(text) != "Numeric (unsigned)" {
+		t.Errorf("Bad marshaled value type: %s, %v", text, err)
+	}
+
+	b, err := json.Marshal(zapi.Item{Type: zapi.ZabbixAgentActive, ValueType: zapi.Unsigned})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wire map[string]interface{}
+	if err = json.Unmarshal(b, &wire); err != nil {
+		t.Fatal(err)
+	}
+	if wire["type"] != "7" || wire["value_type"] != "3" {
+		t.Errorf("Enums should be sent as numeric strings: %s", b)
+	}
+}
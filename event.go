@@ -1,9 +1,25 @@
 package zabbix
 
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type (
 	// Type of the event.
 	// "source" in https://www.zabbix.com/documentation/3.2/manual/api/reference/event/object#event
 	EventType string
+
+	// EventObjectType type of the object related to the event
+	// "object" in https://www.zabbix.com/documentation/5.0/manual/api/reference/event/object
+	EventObjectType int
+
+	// AcknowledgeAction bitmask of the actions performed by event.acknowledge
+	// https://www.zabbix.com/documentation/5.0/manual/api/reference/event/acknowledge
+	AcknowledgeAction int
 )
 
 const (
@@ -16,3 +32,446 @@ const (
 	// internal event
 	InternalEvent EventType = "3"
 )
+
+const (
+	// TriggerObject event related to a trigger
+	TriggerObject EventObjectType = 0
+	// DiscoveredHostObject event related to a discovered host
+	DiscoveredHostObject EventObjectType = 1
+	// DiscoveredServiceObject event related to a discovered service
+	DiscoveredServiceObject EventObjectType = 2
+	// AutoRegisteredHostObject event related to an auto-registered host
+	AutoRegisteredHostObject EventObjectType = 3
+	// ItemObject event related to an item
+	ItemObject EventObjectType = 4
+	// LLDRuleObject event related to a low-level discovery rule
+	LLDRuleObject EventObjectType = 5
+	// ServiceObject event related to a service (new in 6.0)
+	ServiceObject EventObjectType = 6
+)
+
+const (
+	// AcknowledgeClose close the problem
+	AcknowledgeClose AcknowledgeAction = 1
+	// AcknowledgeAck acknowledge the event
+	AcknowledgeAck AcknowledgeAction = 2
+	// AcknowledgeMessage add a message
+	AcknowledgeMessage AcknowledgeAction = 4
+	// AcknowledgeChangeSeverity change the severity
+	AcknowledgeChangeSeverity AcknowledgeAction = 8
+	// AcknowledgeUnack unacknowledge the event (new in 5.0)
+	AcknowledgeUnack AcknowledgeAction = 16
+	// AcknowledgeSuppress suppress the event (new in 6.2)
+	AcknowledgeSuppress AcknowledgeAction = 32
+	// AcknowledgeUnsuppress unsuppress the event (new in 6.2)
+	AcknowledgeUnsuppress AcknowledgeAction = 64
+	// AcknowledgeChangeToCause change the event to a cause (new in 6.4)
+	AcknowledgeChangeToCause AcknowledgeAction = 128
+	// AcknowledgeChangeToSymptom change the event to a symptom of its cause event (new in 6.4)
+	AcknowledgeChangeToSymptom AcknowledgeAction = 256
+)
+
+// UnmarshalJSON accepts strings, numbers and null.
+func (t *EventType) UnmarshalJSON(data []byte) error {
+	s, err := scalarString(data)
+	*t = EventType(s)
+	return err
+}
+
+// MarshalJSON encodes t as a string.
+func (t EventType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(t))
+}
+
+var eventTypeNames = enumNames{
+	kind: "event source",
+	names: map[int][]string{
+		0: {"Triggers", "Trigger"},
+		1: {"Discovery"},
+		2: {"Autoregistration", "Auto registration"},
+		3: {"Internal"},
+	},
+}
+
+func (t EventType) String() string {
+	if v, err := strconv.Atoi(string(t)); err == nil {
+		return eventTypeNames.format(v)
+	}
+	return string(t)
+}
+
+// MarshalText encodes t as its display name.
+func (t EventType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *EventType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseEventType(string(text))
+	return
+}
+
+// ParseEventType Parses an event source from its display name or number.
+func ParseEventType(s string) (EventType, error) {
+	v, err := eventTypeNames.parse(s)
+	if err != nil {
+		return "", err
+	}
+	return EventType(strconv.Itoa(v)), nil
+}
+
+// MarshalJSON encodes t as a string.
+func (t EventObjectType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *EventObjectType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var eventObjectTypeNames = enumNames{
+	kind: "event object",
+	names: map[int][]string{
+		int(TriggerObject):            {"Trigger"},
+		int(DiscoveredHostObject):     {"Discovered host"},
+		int(DiscoveredServiceObject):  {"Discovered service"},
+		int(AutoRegisteredHostObject): {"Auto-registered host", "Autoregistered host"},
+		int(ItemObject):               {"Item"},
+		int(LLDRuleObject):            {"LLD rule", "Discovery rule"},
+		int(ServiceObject):            {"Service"},
+	},
+}
+
+func (t EventObjectType) String() string {
+	return eventObjectTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t EventObjectType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *EventObjectType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseEventObjectType(string(text))
+	return
+}
+
+// ParseEventObjectType Parses an event object from its display name or number.
+func ParseEventObjectType(s string) (EventObjectType, error) {
+	v, err := eventObjectTypeNames.parse(s)
+	return EventObjectType(v), err
+}
+
+// MarshalJSON encodes a as a string.
+func (a AcknowledgeAction) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(a))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (a *AcknowledgeAction) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(a))
+}
+
+// acknowledgeActions lists the actions of the bitmask in order, with their name and the feature they require.
+var acknowledgeActions = []struct {
+	action  AcknowledgeAction
+	name    string
+	feature Feature
+}{
+	{AcknowledgeClose, "close", FeatureAcknowledgeActions},
+	{AcknowledgeAck, "acknowledge", FeatureAcknowledgeActions},
+	{AcknowledgeMessage, "message", FeatureAcknowledgeActions},
+	{AcknowledgeChangeSeverity, "change severity", FeatureAcknowledgeActions},
+	{AcknowledgeUnack, "unacknowledge", FeatureUnacknowledge},
+	{AcknowledgeSuppress, "suppress", FeatureManualSuppression},
+	{AcknowledgeUnsuppress, "unsuppress", FeatureManualSuppression},
+	{AcknowledgeChangeToCause, "change to cause", FeatureCauseSymptom},
+	{AcknowledgeChangeToSymptom, "change to symptom", FeatureCauseSymptom},
+}
+
+// Has Tells if all the actions of other are set in a.
+func (a AcknowledgeAction) Has(other AcknowledgeAction) bool {
+	return a&other == other
+}
+
+func (a AcknowledgeAction) String() string {
+	var names []string
+	rest := a
+	for _, known := range acknowledgeActions {
+		if a.Has(known.action) {
+			names = append(names, known.name)
+			rest &^= known.action
+		}
+	}
+	if rest != 0 || len(names) == 0 {
+		names = append(names, strconv.Itoa(int(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
+// Tag represent a Zabbix tag of an event or an object
+type Tag struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// Tags is an array of Tag
+type Tags []Tag
+
+// EventAcknowledge represent an update made to an event by event.acknowledge
+// "acknowledges" in https://www.zabbix.com/documentation/5.0/manual/api/reference/event/object
+type EventAcknowledge struct {
+	AcknowledgeID string            `json:"acknowledgeid"`
+	UserID        string            `json:"userid"`
+	EventID       string            `json:"eventid"`
+	Clock         Timestamp         `json:"clock"`
+	Message       string            `json:"message"`
+	Action        AcknowledgeAction `json:"action"`
+	OldSeverity   SeverityType      `json:"old_severity"`
+	NewSeverity   SeverityType      `json:"new_severity"`
+	// NOTE: new in 6.2
+	SuppressUntil Timestamp `json:"suppress_until"`
+	// NOTE: new in 6.4
+	TaskID string `json:"taskid,omitempty"`
+}
+
+// EventAcknowledges is an array of EventAcknowledge
+type EventAcknowledges []EventAcknowledge
+
+// EventSuppression represent the reason an event is suppressed for
+// "suppression_data" in https://www.zabbix.com/documentation/5.0/manual/api/reference/event/object
+type EventSuppression struct {
+	MaintenanceID string    `json:"maintenanceid"`
+	SuppressUntil Timestamp `json:"suppress_until"`
+	// NOTE: new in 6.2, set when suppressed by hand
+	UserID string `json:"userid,omitempty"`
+}
+
+// EventSuppressions is an array of EventSuppression
+type EventSuppressions []EventSuppression
+
+// Event represent Zabbix event object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/event/object
+type Event struct {
+	EventID       string          `json:"eventid"`
+	Source        EventType       `json:"source"`
+	Object        EventObjectType `json:"object"`
+	ObjectID      string          `json:"objectid"`
+	Acknowledged  Int             `json:"acknowledged"`
+	Clock         Timestamp       `json:"clock"`
+	Ns            Int             `json:"ns"`
+	Value         Int             `json:"value"`
+	REventID      string          `json:"r_eventid"`
+	CEventID      string          `json:"c_eventid"`
+	CorrelationID string          `json:"correlationid"`
+	UserID        string          `json:"userid"`
+
+	// NOTE: new in 4.0
+	Name       string       `json:"name"`
+	Severity   SeverityType `json:"severity"`
+	Suppressed Int          `json:"suppressed"`
+	// NOTE: new in 5.0
+	OpData string `json:"opdata"`
+	// NOTE: new in 6.4
+	CauseEventID string `json:"cause_eventid"`
+
+	// Hosts of the event in the hosts property, with selectHosts.
+	Hosts Hosts `json:"hosts,omitempty"`
+	// Object related to the event in the relatedObject property, with selectRelatedObject.
+	// Its type depends on Object, see RelatedTrigger.
+	RelatedObject json.RawMessage `json:"relatedObject,omitempty"`
+	// Tags of the event in the tags property, with selectTags.
+	Tags Tags `json:"tags,omitempty"`
+	// Updates of the event in the acknowledges property, with select_acknowledges.
+	Acknowledges EventAcknowledges `json:"acknowledges,omitempty"`
+	// Maintenances and manual suppressions of the event in the suppression_data property, with selectSuppressionData.
+	SuppressionData EventSuppressions `json:"suppression_data,omitempty"`
+}
+
+// Events is an array of Event
+type Events []Event
+
+// RelatedTrigger Decodes the related object of a trigger event, nil for other events or without selectRelatedObject.
+func (e *Event) RelatedTrigger() (res *Trigger, err error) {
+	if e.Object != TriggerObject || len(e.RelatedObject) == 0 {
+		return
+	}
+	var t Trigger
+	if err = unmarshalFlexible(e.RelatedObject, &t); err != nil {
+		return
+	}
+	res = &t
+	return
+}
+
+// ProblemEvent represent Zabbix problem object, an unresolved or recently resolved problem event
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/problem/object
+type ProblemEvent struct {
+	EventID       string          `json:"eventid"`
+	Source        EventType       `json:"source"`
+	Object        EventObjectType `json:"object"`
+	ObjectID      string          `json:"objectid"`
+	Clock         Timestamp       `json:"clock"`
+	Ns            Int             `json:"ns"`
+	REventID      string          `json:"r_eventid"`
+	RClock        Timestamp       `json:"r_clock"`
+	RNs           Int             `json:"r_ns"`
+	CorrelationID string          `json:"correlationid"`
+	UserID        string          `json:"userid"`
+	Name          string          `json:"name"`
+	Acknowledged  Int             `json:"acknowledged"`
+	Severity      SeverityType    `json:"severity"`
+	Suppressed    Int             `json:"suppressed"`
+	// NOTE: new in 5.0
+	OpData string `json:"opdata"`
+	// NOTE: new in 6.4
+	CauseEventID string `json:"cause_eventid"`
+
+	// Tags of the problem in the tags property, with selectTags.
+	Tags Tags `json:"tags,omitempty"`
+	// Updates of the problem in the acknowledges property, with selectAcknowledges.
+	Acknowledges EventAcknowledges `json:"acknowledges,omitempty"`
+	// Maintenances and manual suppressions of the problem in the suppression_data property, with selectSuppressionData.
+	SuppressionData EventSuppressions `json:"suppression_data,omitempty"`
+}
+
+// ProblemEvents is an array of ProblemEvent
+type ProblemEvents []ProblemEvent
+
+// EventsGet Wrapper for event.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/event/get
+func (api *API) EventsGet(params Params) (res Events, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("event.get", params, &res)
+	return
+}
+
+// EventGetByID Gets event by Id only if there is exactly 1 matching event.
+func (api *API) EventGetByID(id string) (res *Event, err error) {
+	events, err := api.EventsGet(Params{"eventids": id})
+	if err != nil {
+		return
+	}
+
+	if len(events) == 1 {
+		res = &events[0]
+	} else {
+		e := ExpectedOneResult(len(events))
+		err = &e
+	}
+	return
+}
+
+// ProblemsGet Wrapper for problem.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/problem/get
+func (api *API) ProblemsGet(params Params) (res ProblemEvents, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("problem.get", params, &res)
+	return
+}
+
+// EventAcknowledgeRequest describes the updates event.acknowledge performs on events
+type EventAcknowledgeRequest struct {
+	EventIDs []string
+
+	Close         bool
+	Acknowledge   bool
+	Unacknowledge bool
+	// Message is added to the events when not empty.
+	Message string
+	// Severity of the events is changed when not nil.
+	Severity *SeverityType
+	Suppress bool
+	// SuppressUntil is the end of the suppression, the zero time suppressing indefinitely.
+	SuppressUntil time.Time
+	Unsuppress    bool
+	// ChangeToCause and ChangeToSymptom rank the events, the latter as symptoms of CauseEventID.
+	ChangeToCause   bool
+	ChangeToSymptom bool
+	CauseEventID    string
+}
+
+// Action Returns the action bitmask of the request.
+func (r *EventAcknowledgeRequest) Action() (a AcknowledgeAction) {
+	for _, flag := range []struct {
+		set    bool
+		action AcknowledgeAction
+	}{
+		{r.Close, AcknowledgeClose},
+		{r.Acknowledge, AcknowledgeAck},
+		{r.Message != "", AcknowledgeMessage},
+		{r.Severity != nil, AcknowledgeChangeSeverity},
+		{r.Unacknowledge, AcknowledgeUnack},
+		{r.Suppress, AcknowledgeSuppress},
+		{r.Unsuppress, AcknowledgeUnsuppress},
+		{r.ChangeToCause, AcknowledgeChangeToCause},
+		{r.ChangeToSymptom, AcknowledgeChangeToSymptom},
+	} {
+		if flag.set {
+			a |= flag.action
+		}
+	}
+	return
+}
+
+// acknowledgeParams checks the request against the server version and returns the event.acknowledge parameters.
+func (api *API) acknowledgeParams(r *EventAcknowledgeRequest) (params Params, err error) {
+	action := r.Action()
+	switch {
+	case action == 0:
+		return nil, errors.New("zabbix: event acknowledge request without any action")
+	case action.Has(AcknowledgeAck | AcknowledgeUnack):
+		return nil, errors.New("zabbix: events cannot be acknowledged and unacknowledged at once")
+	case action.Has(AcknowledgeSuppress | AcknowledgeUnsuppress):
+		return nil, errors.New("zabbix: events cannot be suppressed and unsuppressed at once")
+	case action.Has(AcknowledgeChangeToCause | AcknowledgeChangeToSymptom):
+		return nil, errors.New("zabbix: events cannot be changed to cause and symptom at once")
+	}
+	for _, known := range acknowledgeActions {
+		if action.Has(known.action) {
+			if err = api.requires(known.feature); err != nil {
+				return
+			}
+		}
+	}
+
+	params = Params{"eventids": r.EventIDs, "action": int(action)}
+	if r.Message != "" {
+		params["message"] = r.Message
+	}
+	if r.Severity != nil {
+		params["severity"] = int(*r.Severity)
+	}
+	if r.Suppress {
+		params["suppress_until"] = 0
+		if !r.SuppressUntil.IsZero() {
+			params["suppress_until"] = r.SuppressUntil.Unix()
+		}
+	}
+	if r.ChangeToSymptom {
+		params["cause_eventid"] = r.CauseEventID
+	}
+	return
+}
+
+// EventsAcknowledge Wrapper for event.acknowledge
+// Returns the IDs of the updated events.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/event/acknowledge
+func (api *API) EventsAcknowledge(r EventAcknowledgeRequest) (ids []string, err error) {
+	params, err := api.acknowledgeParams(&r)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("event.acknowledge", params)
+	if err != nil {
+		return
+	}
+	ids = resultIDs(response.Result, "eventids")
+	return
+}
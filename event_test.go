@@ -0,0 +1,62 @@
+package zabbix_test
+
+import (
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestEvents(t *testing.T) {
+	api := testGetAPI(t)
+
+	events, err := api.EventsGet(zapi.Params{
+		"limit":      10,
+		"selectTags": "extend",
+		"sortfield":  []string{"clock", "eventid"},
+		"sortorder":  "DESC",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range events {
+		if e.EventID == "" {
+			t.Errorf("Event without ID: %#v", e)
+		}
+	}
+
+	if _, err = api.ProblemsGet(zapi.Params{"recent": true, "limit": 10}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEventsAcknowledgeErrors(t *testing.T) {
+	api := testGetAPI(t)
+
+	if _, err := api.EventsAcknowledge(zapi.EventAcknowledgeRequest{EventIDs: []string{"1"}}); err == nil {
+		t.Error("Expected an error acknowledging without any action")
+	}
+	if _, err := api.EventsAcknowledge(zapi.EventAcknowledgeRequest{EventIDs: []string{"1"}, Acknowledge: true, Unacknowledge: true}); err == nil {
+		t.Error("Expected an error acknowledging and unacknowledging at once")
+	}
+	if !api.Supports(zapi.FeatureManualSuppression) {
+		_, err := api.EventsAcknowledge(zapi.EventAcknowledgeRequest{EventIDs: []string{"1"}, Suppress: true})
+		if _, ok := err.(*zapi.UnsupportedFeature); !ok {
+			t.Errorf("Expected an UnsupportedFeature error suppressing on Zabbix %s, got %v", api.ServerVersion, err)
+		}
+	}
+}
+
+func TestAcknowledgeAction(t *testing.T) {
+	severity := zapi.High
+	r := zapi.EventAcknowledgeRequest{Acknowledge: true, Message: "on it", Severity: &severity}
+	action := r.Action()
+	if action != zapi.AcknowledgeAck|zapi.AcknowledgeMessage|zapi.AcknowledgeChangeSeverity {
+		t.Errorf("Bad action bitmask %d", action)
+	}
+	if s := action.String(); s != "acknowledge|message|change severity" {
+		t.Errorf("Bad action name %q", s)
+	}
+	if s := (zapi.AcknowledgeClose | 1024).String(); s != "close|1024" {
+		t.Errorf("Bad action name %q", s)
+	}
+}
@@ -0,0 +1,265 @@
+package zabbix
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+type (
+	// Feature is a capability of the Zabbix API which depends on the server version.
+	// Use API.Supports to know if the connected server provides it.
+	Feature int
+)
+
+const (
+	// FeatureHostDeleteByIDs host.delete accepts an array of IDs instead of host objects (new in 2.2)
+	FeatureHostDeleteByIDs Feature = iota
+	// FeatureApplications application API and item applications (removed in 5.4)
+	FeatureApplications
+	// FeatureLegacyItemFields item data_type, delta, delay_flex and multiplier fields (removed in 3.4)
+	FeatureLegacyItemFields
+	// FeatureTags host, template and trigger tags (new in 4.2)
+	FeatureTags
+	// FeatureActionDefaultMessages action default and recovery messages (removed in 5.0)
+	FeatureActionDefaultMessages
+	// FeatureUsername user.login and user objects use "username" instead of "user" and "alias" (new in 5.4)
+	FeatureUsername
+	// FeatureNewExpressionSyntax trigger and calculated item expressions use the 5.4 syntax (new in 5.4)
+	FeatureNewExpressionSyntax
+	// FeatureTemplateGroups templates belong to template groups instead of host groups (new in 6.2)
+	FeatureTemplateGroups
+	// FeatureBearerAuth authentication token is sent in the Authorization header, unless the URL carries basic auth credentials (new in 6.4)
+	FeatureBearerAuth
+	// FeatureTrends trend API (new in 4.0)
+	FeatureTrends
+	// FeatureAcknowledgeActions event.acknowledge takes an action bitmask to close, acknowledge, comment or change severity (new in 4.0)
+	FeatureAcknowledgeActions
+	// FeatureUnacknowledge events can be unacknowledged (new in 5.0)
+	FeatureUnacknowledge
+	// FeatureManualSuppression problems can be suppressed and unsuppressed by hand (new in 6.2)
+	FeatureManualSuppression
+	// FeatureCauseSymptom problems can be ranked as cause or symptom (new in 6.4)
+	FeatureCauseSymptom
+	// FeatureMaintenanceTags maintenance problem tags (new in 4.0)
+	FeatureMaintenanceTags
+	// FeatureMaintenanceObjects maintenance.create and maintenance.update take groups and hosts objects instead of groupids and hostids (new in 6.0)
+	FeatureMaintenanceObjects
+	// FeatureProxyFields proxy name, operating_mode, allowed_addresses, address and port fields, and host proxyid field (new in 7.0)
+	FeatureProxyFields
+	// FeatureProxyGroups proxy groups and hosts monitored by them (new in 7.0)
+	FeatureProxyGroups
+	// FeatureMediaTypeNames media types have a name and a description, and webhooks (new in 4.4)
+	FeatureMediaTypeNames
+	// FeatureMessageTemplates media type message templates (new in 5.0)
+	FeatureMessageTemplates
+	// FeatureUserMedias user.create and user.update take medias instead of user_medias (new in 5.2)
+	FeatureUserMedias
+	// FeatureScriptParameters script media types take parameters objects instead of exec_params (new in 6.4)
+	FeatureScriptParameters
+	// FeatureScriptScopes global script scopes, menu paths, and SSH, Telnet and webhook scripts (new in 5.4)
+	FeatureScriptScopes
+	// FeatureScriptManualInput global script manual input and URL scripts (new in 7.0)
+	FeatureScriptManualInput
+	// FeatureHostValueMaps value maps belong to hosts and templates instead of being global (new in 5.4)
+	FeatureHostValueMaps
+	// FeatureValueMapTypes value map mappings have a type, such as ranges and regular expressions (new in 6.0)
+	FeatureValueMapTypes
+	// FeatureTemplateDashboards template dashboards (new in 5.2)
+	FeatureTemplateDashboards
+	// FeatureDashboardPages dashboards have pages of widgets and can be shown as a slideshow (new in 5.4)
+	FeatureDashboardPages
+	// FeatureItemValueWidgets item value and top hosts dashboard widgets (new in 6.0)
+	FeatureItemValueWidgets
+	// FeatureIndexedWidgetFields widget fields holding several values are named with an index, such as "groupids.0" (new in 6.4)
+	FeatureIndexedWidgetFields
+	// FeatureWideDashboards dashboards have 72 columns and rows of half the height, and widget fields referencing a single object are indexed too (new in 7.0)
+	FeatureWideDashboards
+	// FeatureMapShapes map shapes, and map elements standing for objects given as elements instead of elementid (new in 3.4)
+	FeatureMapShapes
+	// FeatureServices business services with tag based problems and status rules, and SLAs, replacing IT services (new in 6.0)
+	FeatureServices
+	// FeatureHTTPFields web scenario headers, variables, query fields and form fields are name and value pairs (new in 4.0)
+	FeatureHTTPFields
+	// FeatureItemTags item and web scenario tags, replacing applications (new in 5.4)
+	FeatureItemTags
+	// FeatureInventoryMode host prototypes take inventory_mode instead of an inventory object (new in 4.4)
+	FeatureInventoryMode
+	// FeatureCustomInterfaces host prototypes can have their own interfaces instead of those of the discovered host (new in 6.0)
+	FeatureCustomInterfaces
+	// FeatureYAMLFormat configuration.export and configuration.import accept the YAML format (new in 5.2)
+	FeatureYAMLFormat
+	// FeatureImportCompare configuration.importcompare (new in 6.0)
+	FeatureImportCompare
+	// FeatureMacroDescriptions user macro descriptions (new in 4.4)
+	FeatureMacroDescriptions
+	// FeatureSecretMacros user macro types and secret text macros (new in 5.0)
+	FeatureSecretMacros
+	// FeatureVaultMacros Vault secret macros (new in 5.2)
+	FeatureVaultMacros
+	// FeatureUserRoles users have a role instead of a type, and a time zone (new in 5.2)
+	FeatureUserRoles
+	// FeatureUserProvisioning user.provision and current password check when users change their own password (new in 6.4)
+	FeatureUserProvisioning
+	// FeatureUserGroupUsers usergroup.create and usergroup.update take users objects instead of userids (new in 5.2)
+	FeatureUserGroupUsers
+)
+
+// featureRange is the range of server versions providing a feature.
+// A nil since means the feature has always been there, a nil until that it has not been removed.
+type featureRange struct {
+	since *version.Version
+	until *version.Version
+}
+
+var featureNames = map[Feature]string{
+	FeatureHostDeleteByIDs:       "host deletion by IDs",
+	FeatureApplications:          "applications",
+	FeatureLegacyItemFields:      "legacy item fields",
+	FeatureTags:                  "tags",
+	FeatureActionDefaultMessages: "action default messages",
+	FeatureUsername:              "username",
+	FeatureNewExpressionSyntax:   "new expression syntax",
+	FeatureTemplateGroups:        "template groups",
+	FeatureBearerAuth:            "bearer authentication",
+	FeatureTrends:                "trends",
+	FeatureAcknowledgeActions:    "acknowledge actions",
+	FeatureUnacknowledge:         "unacknowledge",
+	FeatureManualSuppression:     "manual suppression",
+	FeatureCauseSymptom:          "cause and symptom events",
+	FeatureMaintenanceTags:       "maintenance tags",
+	FeatureMaintenanceObjects:    "maintenance groups and hosts objects",
+	FeatureProxyFields:           "proxy 7.0 fields",
+	FeatureProxyGroups:           "proxy groups",
+	FeatureMediaTypeNames:        "media type names",
+	FeatureMessageTemplates:      "message templates",
+	FeatureUserMedias:            "user medias",
+	FeatureScriptParameters:      "script media type parameters",
+	FeatureScriptScopes:          "script scopes",
+	FeatureScriptManualInput:     "script manual input",
+	FeatureHostValueMaps:         "host value maps",
+	FeatureValueMapTypes:         "value map mapping types",
+	FeatureTemplateDashboards:    "template dashboards",
+	FeatureDashboardPages:        "dashboard pages",
+	FeatureItemValueWidgets:      "item value and top hosts widgets",
+	FeatureIndexedWidgetFields:   "indexed widget fields",
+	FeatureWideDashboards:        "72 columns dashboards",
+	FeatureMapShapes:             "map shapes",
+	FeatureServices:              "business services",
+	FeatureHTTPFields:            "web scenario fields",
+	FeatureItemTags:              "item tags",
+	FeatureInventoryMode:         "host prototype inventory mode",
+	FeatureCustomInterfaces:      "host prototype custom interfaces",
+	FeatureYAMLFormat:            "YAML configuration format",
+	FeatureImportCompare:         "configuration import comparison",
+	FeatureMacroDescriptions:     "macro descriptions",
+	FeatureSecretMacros:          "secret macros",
+	FeatureVaultMacros:           "Vault secret macros",
+	FeatureUserRoles:             "user roles",
+	FeatureUserProvisioning:      "user provisioning",
+	FeatureUserGroupUsers:        "user group users",
+}
+
+var features = map[Feature]featureRange{
+	FeatureHostDeleteByIDs:       {since: mustVersion("2.2")},
+	FeatureApplications:          {until: mustVersion("5.4")},
+	FeatureLegacyItemFields:      {until: mustVersion("3.4")},
+	FeatureTags:                  {since: mustVersion("4.2")},
+	FeatureActionDefaultMessages: {until: mustVersion("5.0")},
+	FeatureUsername:              {since: mustVersion("5.4")},
+	FeatureNewExpressionSyntax:   {since: mustVersion("5.4")},
+	FeatureTemplateGroups:        {since: mustVersion("6.2")},
+	FeatureBearerAuth:            {since: mustVersion("6.4")},
+	FeatureTrends:                {since: mustVersion("4.0")},
+	FeatureAcknowledgeActions:    {since: mustVersion("4.0")},
+	FeatureUnacknowledge:         {since: mustVersion("5.0")},
+	FeatureManualSuppression:     {since: mustVersion("6.2")},
+	FeatureCauseSymptom:          {since: mustVersion("6.4")},
+	FeatureMaintenanceTags:       {since: mustVersion("4.0")},
+	FeatureMaintenanceObjects:    {since: mustVersion("6.0")},
+	FeatureProxyFields:           {since: mustVersion("7.0")},
+	FeatureProxyGroups:           {since: mustVersion("7.0")},
+	FeatureMediaTypeNames:        {since: mustVersion("4.4")},
+	FeatureMessageTemplates:      {since: mustVersion("5.0")},
+	FeatureUserMedias:            {since: mustVersion("5.2")},
+	FeatureScriptParameters:      {since: mustVersion("6.4")},
+	FeatureScriptScopes:          {since: mustVersion("5.4")},
+	FeatureScriptManualInput:     {since: mustVersion("7.0")},
+	FeatureHostValueMaps:         {since: mustVersion("5.4")},
+	FeatureValueMapTypes:         {since: mustVersion("6.0")},
+	FeatureTemplateDashboards:    {since: mustVersion("5.2")},
+	FeatureDashboardPages:        {since: mustVersion("5.4")},
+	FeatureItemValueWidgets:      {since: mustVersion("6.0")},
+	FeatureIndexedWidgetFields:   {since: mustVersion("6.4")},
+	FeatureWideDashboards:        {since: mustVersion("7.0")},
+	FeatureMapShapes:             {since: mustVersion("3.4")},
+	FeatureServices:              {since: mustVersion("6.0")},
+	FeatureHTTPFields:            {since: mustVersion("4.0")},
+	FeatureItemTags:              {since: mustVersion("5.4")},
+	FeatureInventoryMode:         {since: mustVersion("4.4")},
+	FeatureCustomInterfaces:      {since: mustVersion("6.0")},
+	FeatureYAMLFormat:            {since: mustVersion("5.2")},
+	FeatureImportCompare:         {since: mustVersion("6.0")},
+	FeatureMacroDescriptions:     {since: mustVersion("4.4")},
+	FeatureSecretMacros:          {since: mustVersion("5.0")},
+	FeatureVaultMacros:           {since: mustVersion("5.2")},
+	FeatureUserRoles:             {since: mustVersion("5.2")},
+	FeatureUserProvisioning:      {since: mustVersion("6.4")},
+	FeatureUserGroupUsers:        {since: mustVersion("5.2")},
+}
+
+func mustVersion(v string) *version.Version {
+	return version.Must(version.NewVersion(v))
+}
+
+func (f Feature) String() string {
+	if name, ok := featureNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("Feature(%d)", int(f))
+}
+
+// UnsupportedFeature use to generate error when the server does not provide a feature
+type UnsupportedFeature struct {
+	Feature Feature
+	Version *version.Version
+}
+
+func (e *UnsupportedFeature) Error() string {
+	if e.Version == nil {
+		return fmt.Sprintf("Zabbix server of unknown version does not support %s.", e.Feature)
+	}
+	return fmt.Sprintf("Zabbix server %s does not support %s.", e.Version, e.Feature)
+}
+
+// Supports Tells if the server the API is connected to provides the feature.
+// Pre-releases are considered as the release they precede, and an unknown server version supports nothing.
+func (api *API) Supports(feature Feature) bool {
+	r, ok := features[feature]
+	if !ok || api.ServerVersion == nil {
+		return false
+	}
+
+	v := api.ServerVersion.Core()
+	if r.since != nil && v.LessThan(r.since) {
+		return false
+	}
+	if r.until != nil && v.GreaterThanOrEqual(r.until) {
+		return false
+	}
+	return true
+}
+
+// legacy Tells if the server is known not to provide the feature, and objects must be adapted to it.
+// Objects are sent as they are to a server of unknown version, rather than adapted to the oldest one.
+func (api *API) legacy(feature Feature) bool {
+	return api.ServerVersion != nil && !api.Supports(feature)
+}
+
+// requires returns an UnsupportedFeature error if the server does not provide the feature.
+func (api *API) requires(feature Feature) error {
+	if api.Supports(feature) {
+		return nil
+	}
+	return &UnsupportedFeature{feature, api.ServerVersion}
+}
@@ -0,0 +1,38 @@
+package zabbix_test
+
+import (
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestSupports(t *testing.T) {
+	api := testGetAPI(t)
+
+	cases := []struct {
+		feature zapi.Feature
+		since   string
+		until   string
+	}{
+		{zapi.FeatureApplications, "", "5.4"},
+		{zapi.FeatureUsername, "5.4", ""},
+		{zapi.FeatureTemplateGroups, "6.2", ""},
+		{zapi.FeatureBearerAuth, "6.4", ""},
+	}
+	for _, c := range cases {
+		expected := true
+		if c.since != "" {
+			if less, _ := isVersionLessThan(t, c.since); less {
+				expected = false
+			}
+		}
+		if c.until != "" {
+			if greater, _ := isVersionGreaterThanOrEqual(t, c.until); greater {
+				expected = false
+			}
+		}
+		if api.Supports(c.feature) != expected {
+			t.Errorf("Supports(%s) should be %t on Zabbix %s", c.feature, expected, api.ServerVersion)
+		}
+	}
+}
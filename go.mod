@@ -2,4 +2,4 @@ module github.com/claranet/go-zabbix-api
 
 go 1.18
 
-require github.com/hashicorp/go-version v1.6.0 // indirect
+require github.com/hashicorp/go-version v1.6.0
@@ -0,0 +1,602 @@
+package zabbix
+
+type (
+	// GraphType Graph layout
+	// see "graphtype" in https://www.zabbix.com/documentation/5.0/manual/api/reference/graph/object
+	GraphType int
+
+	// GraphAxisType How the minimum or maximum of the Y axis is found
+	// see "ymin_type" in https://www.zabbix.com/documentation/5.0/manual/api/reference/graph/object
+	GraphAxisType int
+
+	// GraphAxisSide Side of the Y axis a graph item is drawn against
+	// see "yaxisside" in https://www.zabbix.com/documentation/5.0/manual/api/reference/graphitem/object
+	GraphAxisSide int
+
+	// GraphItemCalcFunction Value drawn when several values fall in the same pixel
+	// see "calc_fnc" in https://www.zabbix.com/documentation/5.0/manual/api/reference/graphitem/object
+	GraphItemCalcFunction int
+
+	// GraphItemDrawType Draw style of a graph item
+	// see "drawtype" in https://www.zabbix.com/documentation/5.0/manual/api/reference/graphitem/object
+	GraphItemDrawType int
+
+	// GraphItemType Type of a graph item
+	// see "type" in https://www.zabbix.com/documentation/5.0/manual/api/reference/graphitem/object
+	GraphItemType int
+)
+
+const (
+	// GraphNormal (default) normal graph
+	GraphNormal GraphType = 0
+	// GraphStacked stacked graph
+	GraphStacked GraphType = 1
+	// GraphPie pie graph
+	GraphPie GraphType = 2
+	// GraphExploded exploded pie graph
+	GraphExploded GraphType = 3
+)
+
+const (
+	// AxisCalculated (default) calculated from the values
+	AxisCalculated GraphAxisType = 0
+	// AxisFixed fixed value given by yaxismin or yaxismax
+	AxisFixed GraphAxisType = 1
+	// AxisItem last value of the item given by ymin_itemid or ymax_itemid
+	AxisItem GraphAxisType = 2
+)
+
+const (
+	// AxisLeft (default) left side
+	AxisLeft GraphAxisSide = 0
+	// AxisRight right side
+	AxisRight GraphAxisSide = 1
+)
+
+const (
+	// CalcMin minimum value
+	CalcMin GraphItemCalcFunction = 1
+	// CalcAvg (default) average value
+	CalcAvg GraphItemCalcFunction = 2
+	// CalcMax maximum value
+	CalcMax GraphItemCalcFunction = 4
+	// CalcAll all values, minimum, average and maximum
+	CalcAll GraphItemCalcFunction = 7
+	// CalcLast last value, only for pie graphs
+	CalcLast GraphItemCalcFunction = 9
+)
+
+const (
+	// DrawLine (default) line
+	DrawLine GraphItemDrawType = 0
+	// DrawFilledRegion filled region
+	DrawFilledRegion GraphItemDrawType = 1
+	// DrawBoldLine bold line
+	DrawBoldLine GraphItemDrawType = 2
+	// DrawDot dot
+	DrawDot GraphItemDrawType = 3
+	// DrawDashedLine dashed line
+	DrawDashedLine GraphItemDrawType = 4
+	// DrawGradientLine gradient line
+	DrawGradientLine GraphItemDrawType = 5
+)
+
+const (
+	// GraphItemSimple (default) simple item
+	GraphItemSimple GraphItemType = 0
+	// GraphItemSum item holding the whole of a pie graph
+	GraphItemSum GraphItemType = 2
+)
+
+// MarshalJSON encodes t as a string.
+func (t GraphType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *GraphType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var graphTypeNames = enumNames{
+	kind: "graph type",
+	names: map[int][]string{
+		int(GraphNormal):   {"Normal"},
+		int(GraphStacked):  {"Stacked"},
+		int(GraphPie):      {"Pie"},
+		int(GraphExploded): {"Exploded"},
+	},
+}
+
+func (t GraphType) String() string {
+	return graphTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t GraphType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *GraphType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseGraphType(string(text))
+	return
+}
+
+// ParseGraphType Parses a graph type from its display name or number.
+func ParseGraphType(s string) (GraphType, error) {
+	v, err := graphTypeNames.parse(s)
+	return GraphType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t GraphAxisType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *GraphAxisType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var graphAxisTypeNames = enumNames{
+	kind: "axis type",
+	names: map[int][]string{
+		int(AxisCalculated): {"Calculated"},
+		int(AxisFixed):      {"Fixed"},
+		int(AxisItem):       {"Item"},
+	},
+}
+
+func (t GraphAxisType) String() string {
+	return graphAxisTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t GraphAxisType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *GraphAxisType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseGraphAxisType(string(text))
+	return
+}
+
+// ParseGraphAxisType Parses an axis type from its display name or number.
+func ParseGraphAxisType(s string) (GraphAxisType, error) {
+	v, err := graphAxisTypeNames.parse(s)
+	return GraphAxisType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t GraphAxisSide) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *GraphAxisSide) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var graphAxisSideNames = enumNames{
+	kind: "axis side",
+	names: map[int][]string{
+		int(AxisLeft):  {"Left"},
+		int(AxisRight): {"Right"},
+	},
+}
+
+func (t GraphAxisSide) String() string {
+	return graphAxisSideNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t GraphAxisSide) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *GraphAxisSide) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseGraphAxisSide(string(text))
+	return
+}
+
+// ParseGraphAxisSide Parses an axis side from its display name or number.
+func ParseGraphAxisSide(s string) (GraphAxisSide, error) {
+	v, err := graphAxisSideNames.parse(s)
+	return GraphAxisSide(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t GraphItemCalcFunction) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *GraphItemCalcFunction) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var graphItemCalcFunctionNames = enumNames{
+	kind: "calculation function",
+	names: map[int][]string{
+		int(CalcMin):  {"Min", "Minimum"},
+		int(CalcAvg):  {"Avg", "Average"},
+		int(CalcMax):  {"Max", "Maximum"},
+		int(CalcAll):  {"All"},
+		int(CalcLast): {"Last"},
+	},
+}
+
+func (t GraphItemCalcFunction) String() string {
+	return graphItemCalcFunctionNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t GraphItemCalcFunction) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *GraphItemCalcFunction) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseGraphItemCalcFunction(string(text))
+	return
+}
+
+// ParseGraphItemCalcFunction Parses a calculation function from its display name or number.
+func ParseGraphItemCalcFunction(s string) (GraphItemCalcFunction, error) {
+	v, err := graphItemCalcFunctionNames.parse(s)
+	return GraphItemCalcFunction(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t GraphItemDrawType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *GraphItemDrawType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var graphItemDrawTypeNames = enumNames{
+	kind: "draw style",
+	names: map[int][]string{
+		int(DrawLine):         {"Line"},
+		int(DrawFilledRegion): {"Filled region"},
+		int(DrawBoldLine):     {"Bold line"},
+		int(DrawDot):          {"Dot"},
+		int(DrawDashedLine):   {"Dashed line"},
+		int(DrawGradientLine): {"Gradient line"},
+	},
+}
+
+func (t GraphItemDrawType) String() string {
+	return graphItemDrawTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t GraphItemDrawType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *GraphItemDrawType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseGraphItemDrawType(string(text))
+	return
+}
+
+// ParseGraphItemDrawType Parses a draw style from its display name or number.
+func ParseGraphItemDrawType(s string) (GraphItemDrawType, error) {
+	v, err := graphItemDrawTypeNames.parse(s)
+	return GraphItemDrawType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t GraphItemType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *GraphItemType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var graphItemTypeNames = enumNames{
+	kind: "graph item type",
+	names: map[int][]string{
+		int(GraphItemSimple): {"Simple"},
+		int(GraphItemSum):    {"Graph sum"},
+	},
+}
+
+func (t GraphItemType) String() string {
+	return graphItemTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t GraphItemType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *GraphItemType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseGraphItemType(string(text))
+	return
+}
+
+// ParseGraphItemType Parses a graph item type from its display name or number.
+func ParseGraphItemType(s string) (GraphItemType, error) {
+	v, err := graphItemTypeNames.parse(s)
+	return GraphItemType(v), err
+}
+
+// GraphItem represent Zabbix graph item object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graphitem/object
+type GraphItem struct {
+	GraphItemID string                `json:"gitemid,omitempty"` // Readonly
+	ItemID      string                `json:"itemid"`            // Required
+	Color       string                `json:"color"`             // Required, hexadecimal RGB such as "1A7C11"
+	CalcFunc    GraphItemCalcFunction `json:"calc_fnc,omitempty"`
+	DrawType    GraphItemDrawType     `json:"drawtype"`
+	SortOrder   Int                   `json:"sortorder"`
+	Type        GraphItemType         `json:"type"`
+	YAxisSide   GraphAxisSide         `json:"yaxisside"`
+}
+
+// GraphItems is an array of GraphItem
+type GraphItems []GraphItem
+
+// Graph represent Zabbix graph object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graph/object
+type Graph struct {
+	GraphID      string    `json:"graphid,omitempty"` // Readonly
+	Name         string    `json:"name"`              // Required
+	Width        Int       `json:"width"`             // Required
+	Height       Int       `json:"height"`            // Required
+	GraphType    GraphType `json:"graphtype"`
+	PercentLeft  Number    `json:"percent_left"`
+	PercentRight Number    `json:"percent_right"`
+
+	// The flags below are sent as they are, see NewGraph for the defaults of the server.
+	Show3D         Int `json:"show_3d"`
+	ShowLegend     Int `json:"show_legend"`
+	ShowWorkPeriod Int `json:"show_work_period"`
+	ShowTriggers   Int `json:"show_triggers"`
+
+	YMinType   GraphAxisType `json:"ymin_type"`
+	YMin       Number        `json:"yaxismin"`              // used with AxisFixed
+	YMinItemID string        `json:"ymin_itemid,omitempty"` // used with AxisItem, "0" for none
+	YMaxType   GraphAxisType `json:"ymax_type"`
+	YMax       Number        `json:"yaxismax"`              // used with AxisFixed
+	YMaxItemID string        `json:"ymax_itemid,omitempty"` // used with AxisItem, "0" for none
+
+	// Items are required on creation and returned with selectGraphItems.
+	Items GraphItems `json:"gitems,omitempty"`
+}
+
+// Graphs is an array of Graph
+type Graphs []Graph
+
+// GraphPrototype represent Zabbix graph prototype object, a graph of item prototypes
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graphprototype/object
+type GraphPrototype struct {
+	Graph
+	// Discover 1 to not create graphs from the prototype
+	Discover Int `json:"discover,omitempty"`
+}
+
+// GraphPrototypes is an array of GraphPrototype
+type GraphPrototypes []GraphPrototype
+
+// graphPalette are the colours given in turn to the items of built graphs
+var graphPalette = []string{
+	"1A7C11", "F63100", "2774A4", "A54F10", "FC6EA3", "6C59DC", "AC8C14",
+	"611F27", "F230E0", "5CCD18", "BB2A02", "5A2B57", "89ABF8", "7EC25C",
+	"274482", "2B5429", "8048B4", "FD5434", "790E1F", "87AC4D", "E89DF4",
+}
+
+// NewGraph Builds a graph of the items with the default size and flags of the server.
+// Items are drawn as lines of their average value, in turn with the colours of the palette.
+func NewGraph(name string, items Items) Graph {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ItemID
+	}
+	return newGraph(name, ids)
+}
+
+// NewGraphPrototype Builds a graph prototype of the item prototypes, as NewGraph does.
+func NewGraphPrototype(name string, items ItemPrototypes) GraphPrototype {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ItemID
+	}
+	return GraphPrototype{Graph: newGraph(name, ids)}
+}
+
+func newGraph(name string, itemIDs []string) Graph {
+	g := Graph{
+		Name:           name,
+		Width:          900,
+		Height:         200,
+		ShowLegend:     1,
+		ShowWorkPeriod: 1,
+		ShowTriggers:   1,
+		YMax:           100,
+		Items:          make(GraphItems, len(itemIDs)),
+	}
+	for i, id := range itemIDs {
+		g.Items[i] = GraphItem{
+			ItemID:    id,
+			Color:     graphPalette[i%len(graphPalette)],
+			CalcFunc:  CalcAvg,
+			DrawType:  DrawLine,
+			SortOrder: Int(i),
+		}
+	}
+	return g
+}
+
+// GraphsGet Wrapper for graph.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graph/get
+func (api *API) GraphsGet(params Params) (res Graphs, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("graph.get", params, &res)
+	return
+}
+
+// GraphGetByID Gets graph by Id only if there is exactly 1 matching graph.
+// Its items are selected.
+func (api *API) GraphGetByID(id string) (res *Graph, err error) {
+	graphs, err := api.GraphsGet(Params{"graphids": id, "selectGraphItems": "extend"})
+	if err != nil {
+		return
+	}
+
+	if len(graphs) == 1 {
+		res = &graphs[0]
+	} else {
+		e := ExpectedOneResult(len(graphs))
+		err = &e
+	}
+	return
+}
+
+// GraphsCreate Wrapper for graph.create
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graph/create
+func (api *API) GraphsCreate(graphs Graphs) (err error) {
+	response, err := api.CallWithError("graph.create", graphs)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "graphids") {
+		graphs[i].GraphID = id
+	}
+	return
+}
+
+// GraphsUpdate Wrapper for graph.update
+// Items given replace the items of the graph.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graph/update
+func (api *API) GraphsUpdate(graphs Graphs) (err error) {
+	_, err = api.CallWithError("graph.update", graphs)
+	return
+}
+
+// GraphsDelete Wrapper for graph.delete
+// Cleans GraphID in all graphs elements if call succeed.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graph/delete
+func (api *API) GraphsDelete(graphs Graphs) (err error) {
+	ids := make([]string, len(graphs))
+	for i, graph := range graphs {
+		ids[i] = graph.GraphID
+	}
+
+	err = api.GraphsDeleteByIds(ids)
+	if err == nil {
+		for i := range graphs {
+			graphs[i].GraphID = ""
+		}
+	}
+	return
+}
+
+// GraphsDeleteByIds Wrapper for graph.delete
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graph/delete
+func (api *API) GraphsDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("graph.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "graphids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// GraphPrototypesGet Wrapper for graphprototype.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graphprototype/get
+func (api *API) GraphPrototypesGet(params Params) (res GraphPrototypes, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("graphprototype.get", params, &res)
+	return
+}
+
+// GraphPrototypeGetByID Gets graph prototype by Id only if there is exactly 1 matching graph prototype.
+// Its items are selected.
+func (api *API) GraphPrototypeGetByID(id string) (res *GraphPrototype, err error) {
+	graphs, err := api.GraphPrototypesGet(Params{"graphids": id, "selectGraphItems": "extend"})
+	if err != nil {
+		return
+	}
+
+	if len(graphs) == 1 {
+		res = &graphs[0]
+	} else {
+		e := ExpectedOneResult(len(graphs))
+		err = &e
+	}
+	return
+}
+
+// GraphPrototypesCreate Wrapper for graphprototype.create
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graphprototype/create
+func (api *API) GraphPrototypesCreate(graphs GraphPrototypes) (err error) {
+	response, err := api.CallWithError("graphprototype.create", graphs)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "graphids") {
+		graphs[i].GraphID = id
+	}
+	return
+}
+
+// GraphPrototypesUpdate Wrapper for graphprototype.update
+// Items given replace the items of the graph prototype.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graphprototype/update
+func (api *API) GraphPrototypesUpdate(graphs GraphPrototypes) (err error) {
+	_, err = api.CallWithError("graphprototype.update", graphs)
+	return
+}
+
+// GraphPrototypesDelete Wrapper for graphprototype.delete
+// Cleans GraphID in all graph prototypes elements if call succeed.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graphprototype/delete
+func (api *API) GraphPrototypesDelete(graphs GraphPrototypes) (err error) {
+	ids := make([]string, len(graphs))
+	for i, graph := range graphs {
+		ids[i] = graph.GraphID
+	}
+
+	err = api.GraphPrototypesDeleteByIds(ids)
+	if err == nil {
+		for i := range graphs {
+			graphs[i].GraphID = ""
+		}
+	}
+	return
+}
+
+// GraphPrototypesDeleteByIds Wrapper for graphprototype.delete
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/graphprototype/delete
+func (api *API) GraphPrototypesDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("graphprototype.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "graphids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
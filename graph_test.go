@@ -0,0 +1,155 @@
+package zabbix_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestNewGraph(t *testing.T) {
+	items := make(zapi.Items, 23)
+	for i := range items {
+		items[i].ItemID = fmt.Sprint(i + 1)
+	}
+	graph := zapi.NewGraph("graph", items)
+	if graph.Name != "graph" || graph.Width == 0 || graph.Height == 0 || graph.ShowLegend != 1 {
+		t.Errorf("Unexpected graph: %#v", graph)
+	}
+	if len(graph.Items) != len(items) {
+		t.Fatalf("Graph has %d items instead of %d", len(graph.Items), len(items))
+	}
+	colors := make(map[string]int)
+	for i, item := range graph.Items {
+		if item.ItemID != items[i].ItemID || int(item.SortOrder) != i || item.CalcFunc != zapi.CalcAvg {
+			t.Errorf("Unexpected graph item %d: %#v", i, item)
+		}
+		if len(item.Color) != 6 {
+			t.Errorf("Graph item %d has bad color %q", i, item.Color)
+		}
+		colors[item.Color]++
+	}
+	// the palette is only reused after all its colours
+	if len(colors) != 21 || graph.Items[0].Color != graph.Items[21].Color {
+		t.Errorf("Unexpected colors: %v", colors)
+	}
+
+	prototype := zapi.NewGraphPrototype("prototype", zapi.ItemPrototypes{{ItemID: "1"}})
+	if prototype.Name != "prototype" || len(prototype.Items) != 1 || prototype.Items[0].Color != graph.Items[0].Color {
+		t.Errorf("Unexpected graph prototype: %#v", prototype)
+	}
+}
+
+func TestGraphs(t *testing.T) {
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	item := testCreateItem(host, t)
+	defer testDeleteItem(item, t)
+
+	graphs := zapi.Graphs{zapi.NewGraph(fmt.Sprintf("zabbix-testing-%d", rand.Int()), zapi.Items{*item})}
+	graphs[0].YMinType = zapi.AxisFixed
+	graphs[0].YMin = -10
+	err := api.GraphsCreate(graphs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph := &graphs[0]
+	if graph.GraphID == "" {
+		t.Errorf("Graph ID is empty: %#v", graph)
+	}
+
+	graph2, err := api.GraphGetByID(graph.GraphID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if graph2.Name != graph.Name || graph2.YMinType != zapi.AxisFixed || graph2.YMin != -10 {
+		t.Errorf("Graphs are not equal:\n%#v\n%#v", graph, graph2)
+	}
+	if len(graph2.Items) != 1 || graph2.Items[0].ItemID != item.ItemID || graph2.Items[0].Color != graph.Items[0].Color {
+		t.Errorf("Unexpected graph items: %#v", graph2.Items)
+	}
+
+	graph2.GraphType = zapi.GraphStacked
+	graph2.Items[0].DrawType = zapi.DrawFilledRegion
+	err = api.GraphsUpdate(zapi.Graphs{*graph2})
+	if err != nil {
+		t.Error(err)
+	}
+	graph3, err := api.GraphGetByID(graph.GraphID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if graph3.GraphType != zapi.GraphStacked || len(graph3.Items) != 1 || graph3.Items[0].DrawType != zapi.DrawFilledRegion {
+		t.Errorf("Graph not updated: %#v", graph3)
+	}
+
+	err = api.GraphsDelete(graphs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if graph.GraphID != "" {
+		t.Errorf("Graph ID is not cleaned: %#v", graph)
+	}
+}
+
+func TestGraphPrototypes(t *testing.T) {
+	api := testGetAPI(t)
+
+	// Zabbix v6.2 introduced Template Groups and requires them for Templates
+	var groupIds zapi.HostGroupIDs
+	if compLessThan, _ := isVersionLessThan(t, "6.2"); compLessThan {
+		hostGroup := testCreateHostGroup(t)
+		defer testDeleteHostGroup(hostGroup, t)
+		groupIds = zapi.HostGroupIDs{{GroupID: hostGroup.GroupID}}
+	} else {
+		templateGroup := testCreateTemplateGroup(t)
+		defer testDeleteTemplateGroup(templateGroup, t)
+		groupIds = zapi.HostGroupIDs{{GroupID: templateGroup.GroupID}}
+	}
+
+	template := testCreateTemplate(&groupIds, t)
+	defer testDeleteTemplate(template, t)
+
+	lldRule := testCreateLLDRule(template, t)
+	defer testDeleteLLDRule(lldRule, t)
+
+	itemPrototype := testCreateItemPrototype(template, lldRule, t)
+	defer testDeleteItemPrototype(itemPrototype, t)
+
+	prototypes := zapi.GraphPrototypes{zapi.NewGraphPrototype("Disk space on {#FSNAME}", zapi.ItemPrototypes{*itemPrototype})}
+	err := api.GraphPrototypesCreate(prototypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prototype := &prototypes[0]
+	if prototype.GraphID == "" {
+		t.Errorf("Graph prototype ID is empty: %#v", prototype)
+	}
+
+	prototype.Name = "Free disk space on {#FSNAME}"
+	prototype.Items = nil
+	err = api.GraphPrototypesUpdate(prototypes)
+	if err != nil {
+		t.Error(err)
+	}
+
+	prototype2, err := api.GraphPrototypeGetByID(prototype.GraphID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prototype2.Name != prototype.Name || len(prototype2.Items) != 1 || prototype2.Items[0].ItemID != itemPrototype.ItemID {
+		t.Errorf("Unexpected graph prototype: %#v", prototype2)
+	}
+
+	err = api.GraphPrototypesDelete(prototypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,181 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// historyPageSize is the number of records requested at once by HistoryGet
+const historyPageSize = 10000
+
+// HistoryLogEntry holds the properties specific to the history of Log items
+type HistoryLogEntry struct {
+	Timestamp time.Time // time of the log entry
+	Source    string
+	Severity  int
+	EventID   int
+}
+
+// HistoryRecord represent Zabbix history object, with a value typed according to the item value type
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/history/object
+type HistoryRecord struct {
+	ItemID    string
+	Clock     time.Time
+	ValueType ValueType
+
+	Float    float64          // value of Float items
+	Unsigned uint64           // value of Unsigned items
+	Text     string           // value of Character, Log and Text items
+	Log      *HistoryLogEntry // set for Log items
+}
+
+// HistoryRecords is an array of HistoryRecord
+type HistoryRecords []HistoryRecord
+
+// historyObject is a history object as returned by history.get
+type historyObject struct {
+	ItemID     string          `json:"itemid"`
+	Clock      json.Number     `json:"clock"`
+	Ns         json.Number     `json:"ns"`
+	Value      json.RawMessage `json:"value"`
+	Timestamp  json.Number     `json:"timestamp"`
+	Source     string          `json:"source"`
+	Severity   Int             `json:"severity"`
+	LogEventID Int             `json:"logeventid"`
+}
+
+// record converts o to a HistoryRecord of the given value type.
+func (o historyObject) record(valueType ValueType) (r HistoryRecord, err error) {
+	r.ItemID = o.ItemID
+	r.ValueType = valueType
+	if r.Clock, err = ParseClockNs(o.Clock.String(), o.Ns.String()); err != nil {
+		return
+	}
+	value, err := scalarString(o.Value)
+	if err != nil {
+		return
+	}
+
+	switch valueType {
+	case Float:
+		r.Float, err = strconv.ParseFloat(value, 64)
+	case Unsigned:
+		r.Unsigned, err = strconv.ParseUint(value, 10, 64)
+	case Log:
+		r.Text = value
+		r.Log = &HistoryLogEntry{Source: o.Source, Severity: int(o.Severity), EventID: int(o.LogEventID)}
+		r.Log.Timestamp, err = ParseClock(o.Timestamp.String())
+	default:
+		r.Text = value
+	}
+	if err != nil {
+		err = fmt.Errorf("zabbix: bad %s history value %q of item %s: %v", valueType, value, o.ItemID, err)
+	}
+	return
+}
+
+// HistoryGet Wrapper for history.get
+// Gets the history of the items between from and till, both optional, ordered by time.
+// The history parameter is chosen from the value type of each item, and large ranges are fetched in several calls.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/history/get
+func (api *API) HistoryGet(itemIDs []string, from, till time.Time) (res HistoryRecords, err error) {
+	if len(itemIDs) == 0 {
+		return
+	}
+	items, err := api.ItemsGet(Params{
+		"itemids":  itemIDs,
+		"output":   []string{"itemid", "value_type"},
+		"webitems": true,
+	})
+	if err != nil {
+		return
+	}
+
+	byValueType := make(map[ValueType][]string)
+	for _, item := range items {
+		byValueType[item.ValueType] = append(byValueType[item.ValueType], item.ItemID)
+	}
+	for valueType, ids := range byValueType {
+		var records HistoryRecords
+		if records, err = api.historyGetByValueType(valueType, ids, from, till); err != nil {
+			return nil, err
+		}
+		res = append(res, records...)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Clock.Before(res[j].Clock)
+	})
+	return
+}
+
+// historyGetByValueType pages through the history of items sharing the same value type.
+// Each page starts at the clock of the last record of the previous one, whose records are skipped when seen again.
+// history.get cannot page within a second, so a full page of records sharing one clock is an error rather than a silent gap.
+func (api *API) historyGetByValueType(valueType ValueType, ids []string, from, till time.Time) (res HistoryRecords, err error) {
+	params := Params{
+		"history":   int(valueType),
+		"itemids":   ids,
+		"output":    "extend",
+		"sortfield": "clock",
+		"sortorder": "ASC",
+		"limit":     historyPageSize,
+	}
+	if !till.IsZero() {
+		params["time_till"] = till.Unix()
+	}
+	timeFrom := from
+
+	seen := make(map[string]bool)
+	for {
+		if !timeFrom.IsZero() {
+			params["time_from"] = timeFrom.Unix()
+		}
+		var page []historyObject
+		if err = api.CallWithErrorParse("history.get", params, &page); err != nil {
+			return
+		}
+
+		if len(page) == 0 {
+			return
+		}
+		lastClock := page[len(page)-1].clockUnix()
+		if len(page) == historyPageSize && page[0].clockUnix() == lastClock {
+			return nil, fmt.Errorf("zabbix: more than %d %s history records at %s, cannot page through them",
+				historyPageSize, valueType, time.Unix(lastClock, 0).UTC().Format(time.RFC3339))
+		}
+
+		added := 0
+		lastSeen := make(map[string]bool)
+		for _, o := range page {
+			key := fmt.Sprintf("%s/%s/%s", o.ItemID, o.Clock, o.Ns)
+			var r HistoryRecord
+			if r, err = o.record(valueType); err != nil {
+				return
+			}
+			if o.clockUnix() == lastClock {
+				lastSeen[key] = true
+			}
+			if seen[key] {
+				continue
+			}
+			res = append(res, r)
+			added++
+		}
+
+		if len(page) < historyPageSize || added == 0 {
+			return
+		}
+		timeFrom = time.Unix(lastClock, 0)
+		seen = lastSeen
+	}
+}
+
+// clockUnix returns the clock of o, already validated by record.
+func (o historyObject) clockUnix() int64 {
+	clock, _ := o.Clock.Int64()
+	return clock
+}
@@ -0,0 +1,82 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestHistory(t *testing.T) {
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	item := testCreateItem(host, t)
+	defer testDeleteItem(item, t)
+
+	records, err := api.HistoryGet([]string{item.ItemID}, time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 {
+		t.Errorf("Expected no history for a new item, got %d records", len(records))
+	}
+}
+
+// testFakeHistory returns a fake server holding the history of the unsigned item 1,
+// count records with the given number of records per second.
+func testFakeHistory(t *testing.T, count, perSecond int) *zapi.API {
+	return testFakeAPI(t, "6.0.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		if method == "item.get" {
+			return `[{"itemid":"1","value_type":"3"}]`, nil
+		}
+		var p struct {
+			TimeFrom int64 `json:"time_from"`
+			Limit    int   `json:"limit"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return "", &zapi.Error{Code: -32602, Message: err.Error()}
+		}
+		var page []string
+		for i := 0; i < count && len(page) < p.Limit; i++ {
+			clock := int64(1000 + i/perSecond)
+			if clock >= p.TimeFrom {
+				page = append(page, fmt.Sprintf(`{"itemid":"1","clock":"%d","ns":"%d","value":"%d"}`, clock, i%perSecond, i))
+			}
+		}
+		return "[" + strings.Join(page, ",") + "]", nil
+	})
+}
+
+func TestHistoryPaging(t *testing.T) {
+	const count = 25000
+	api := testFakeHistory(t, count, 3)
+	records, err := api.HistoryGet([]string{"1"}, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != count {
+		t.Fatalf("Expected %d records, got %d", count, len(records))
+	}
+	for i, r := range records {
+		if r.Unsigned != uint64(i) {
+			t.Fatalf("Record %d has value %d", i, r.Unsigned)
+		}
+	}
+}
+
+func TestHistoryPagingSameClock(t *testing.T) {
+	api := testFakeHistory(t, 10001, 10001)
+	if _, err := api.HistoryGet([]string{"1"}, time.Time{}, time.Time{}); err == nil {
+		t.Error("Expected an error for more records in one second than a page holds")
+	}
+}
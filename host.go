@@ -8,6 +8,10 @@ type (
 	// StatusType Status and function of the host.
 	// see "status" in:	https://www.zabbix.com/documentation/3.2/manual/api/reference/host/object
 	StatusType int
+
+	// MonitoredBy Source monitoring the host, new in 7.0
+	// see "monitored_by" in: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/host/object
+	MonitoredBy int
 )
 
 const (
@@ -26,15 +30,151 @@ const (
 	Unmonitored StatusType = 1
 )
 
+const (
+	// MonitoredByServer (default) host monitored by the server
+	MonitoredByServer MonitoredBy = 0
+	// MonitoredByProxy host monitored by the proxy given by proxyid
+	MonitoredByProxy MonitoredBy = 1
+	// MonitoredByProxyGroup host monitored by a proxy of the group given by proxy_groupid
+	MonitoredByProxyGroup MonitoredBy = 2
+)
+
+// MarshalJSON encodes t as a string.
+func (t AvailableType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *AvailableType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t StatusType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *StatusType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var availableTypeNames = enumNames{
+	kind: "availability",
+	names: map[int][]string{
+		int(Unknown):     {"Unknown"},
+		int(Available):   {"Available"},
+		int(Unavailable): {"Unavailable"},
+	},
+}
+
+func (t AvailableType) String() string {
+	return availableTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t AvailableType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *AvailableType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseAvailableType(string(text))
+	return
+}
+
+// ParseAvailableType Parses an availability from its display name or number.
+func ParseAvailableType(s string) (AvailableType, error) {
+	v, err := availableTypeNames.parse(s)
+	return AvailableType(v), err
+}
+
+var statusTypeNames = enumNames{
+	kind: "status",
+	names: map[int][]string{
+		int(Enabled):  {"Enabled", "Monitored"},
+		int(Disabled): {"Disabled", "Unmonitored"},
+	},
+}
+
+func (t StatusType) String() string {
+	return statusTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t StatusType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *StatusType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseStatusType(string(text))
+	return
+}
+
+// ParseStatusType Parses a status from its display name or number.
+func ParseStatusType(s string) (StatusType, error) {
+	v, err := statusTypeNames.parse(s)
+	return StatusType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t MonitoredBy) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MonitoredBy) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var monitoredByNames = enumNames{
+	kind: "monitoring source",
+	names: map[int][]string{
+		int(MonitoredByServer):     {"Server"},
+		int(MonitoredByProxy):      {"Proxy"},
+		int(MonitoredByProxyGroup): {"Proxy group"},
+	},
+}
+
+func (t MonitoredBy) String() string {
+	return monitoredByNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MonitoredBy) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MonitoredBy) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMonitoredBy(string(text))
+	return
+}
+
+// ParseMonitoredBy Parses a monitoring source from its display name or number.
+func ParseMonitoredBy(s string) (MonitoredBy, error) {
+	v, err := monitoredByNames.parse(s)
+	return MonitoredBy(v), err
+}
+
 // Host represent Zabbix host object
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/host/object
 type Host struct {
 	HostID    string        `json:"hostid,omitempty"`
 	Host      string        `json:"host"`
-	Available AvailableType `json:"available,string"`
+	Available AvailableType `json:"available"`
 	Error     string        `json:"error"`
 	Name      string        `json:"name"`
-	Status    StatusType    `json:"status,string"`
+	Status    StatusType    `json:"status"`
+
+	// Proxy monitoring the host, empty for none or unchanged.
+	// NOTE: proxy_hostid before Zabbix 7.0
+	ProxyID string `json:"proxyid,omitempty"`
+	// Source monitoring the host, nil for unchanged; set it to MonitoredByServer to remove the proxy.
+	// NOTE: new in 7.0, set from ProxyID and ProxyGroupID when not given
+	MonitoredBy  *MonitoredBy `json:"monitored_by,omitempty"`
+	ProxyGroupID string       `json:"proxy_groupid,omitempty"`
 
 	// Fields below used if specified selectInterfaces or selectMacros or selectParentTemplates parameters
 	Interfaces HostInterfaces `json:"interfaces,omitempty"`
@@ -49,6 +189,74 @@ type Host struct {
 // Hosts is an array of Host
 type Hosts []Host
 
+var hostFields = fieldRules{
+	{Field: "proxyid", Feature: FeatureProxyFields, Legacy: "proxy_hostid"},
+	{Field: "monitored_by", Feature: FeatureProxyGroups},
+	{Field: "proxy_groupid", Feature: FeatureProxyGroups},
+}
+
+// UnmarshalJSON decodes the proxy of the host whatever the server version, "0" meaning none.
+func (h *Host) UnmarshalJSON(data []byte) (err error) {
+	if data, err = hostFields.canonical(data); err != nil {
+		return
+	}
+	type host Host
+	if err = unmarshalFlexible(data, (*host)(h)); err != nil {
+		return
+	}
+	if h.ProxyID == "0" {
+		h.ProxyID = ""
+	}
+	if h.ProxyGroupID == "0" {
+		h.ProxyGroupID = ""
+	}
+	return
+}
+
+// hostParams returns the parameters of host.create and host.update for the server version.
+// The monitoring source is only sent when given, so that a partial update leaves the proxy of the host alone.
+func (api *API) hostParams(hosts Hosts) (params interface{}, err error) {
+	if params, err = api.marshalFor(hosts, hostFields); err != nil {
+		return
+	}
+	if err = api.adaptMacros(params); err != nil {
+		return
+	}
+	for i, o := range params.([]interface{}) {
+		object := o.(map[string]interface{})
+		if api.legacy(FeatureProxyGroups) {
+			if hosts[i].MonitoredBy != nil && *hosts[i].MonitoredBy == MonitoredByServer && hosts[i].ProxyID == "" {
+				key := "proxyid"
+				if api.legacy(FeatureProxyFields) {
+					key = "proxy_hostid"
+				}
+				object[key] = "0"
+			}
+			continue
+		}
+		if _, present := object["monitored_by"]; present || !api.Supports(FeatureProxyGroups) {
+			continue
+		}
+		if proxy, present := object["proxyid"].(string); present {
+			object["monitored_by"] = MonitoredByServer
+			if proxy != "0" {
+				object["monitored_by"] = MonitoredByProxy
+			}
+		} else if group, _ := object["proxy_groupid"].(string); group != "" && group != "0" {
+			object["monitored_by"] = MonitoredByProxyGroup
+		}
+	}
+	return
+}
+
+// HostID represent Zabbix HostID
+type HostID struct {
+	HostID string `json:"hostid"`
+}
+
+// HostIDs is an array of HostID
+type HostIDs []HostID
+
 // HostsGet Wrapper for host.get
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/host/get
 func (api *API) HostsGet(params Params) (res Hosts, err error) {
@@ -108,15 +316,17 @@ func (api *API) HostGetByHost(host string) (res *Host, err error) {
 // HostsCreate Wrapper for host.create
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/host/create
 func (api *API) HostsCreate(hosts Hosts) (err error) {
-	response, err := api.CallWithError("host.create", hosts)
+	params, err := api.hostParams(hosts)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("host.create", params)
 	if err != nil {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	hostids := result["hostids"].([]interface{})
-	for i, id := range hostids {
-		hosts[i].HostID = id.(string)
+	for i, id := range resultIDs(response.Result, "hostids") {
+		hosts[i].HostID = id
 	}
 	return
 }
@@ -124,7 +334,11 @@ func (api *API) HostsCreate(hosts Hosts) (err error) {
 // HostsUpdate Wrapper for host.update
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/host/update
 func (api *API) HostsUpdate(hosts Hosts) (err error) {
-	_, err = api.CallWithError("host.update", hosts)
+	params, err := api.hostParams(hosts)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("host.update", params)
 	return
 }
 
@@ -149,25 +363,31 @@ func (api *API) HostsDelete(hosts Hosts) (err error) {
 // HostsDeleteByIds Wrapper for host.delete
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/host/delete
 func (api *API) HostsDeleteByIds(ids []string) (err error) {
-	response, err := api.CallWithError("host.delete", ids)
-	if err != nil {
+	// Zabbix 2.0 and older use old syntax only with hostid
+	hostIds := make([]map[string]string, len(ids))
+	for i, id := range ids {
+		hostIds[i] = map[string]string{"hostid": id}
+	}
+
+	var response Response
+	switch {
+	case api.ServerVersion == nil:
+		// unknown version, try the new syntax first
+		response, err = api.CallWithError("host.delete", ids)
 		if e, ok := err.(*Error); ok && e.Code == -32500 {
-			// Zabbix 2.0 and older use old syntax only with hostid
-			hostIds := make([]map[string]string, len(ids))
-			for i, id := range ids {
-				hostIds[i] = map[string]string{"hostid": id}
-			}
 			response, err = api.CallWithError("host.delete", hostIds)
 		}
+	case api.Supports(FeatureHostDeleteByIDs):
+		response, err = api.CallWithError("host.delete", ids)
+	default:
+		response, err = api.CallWithError("host.delete", hostIds)
 	}
 	if err != nil {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	hostids := result["hostids"].([]interface{})
-	if len(ids) != len(hostids) {
-		err = &ExpectedMore{len(ids), len(hostids)}
+	if deleted := resultIDs(response.Result, "hostids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
 	}
 	return
 }
@@ -13,12 +13,51 @@ const (
 	Internal InternalType = 1
 )
 
+// MarshalJSON encodes t as a string.
+func (t InternalType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *InternalType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var internalTypeNames = enumNames{
+	kind: "internal flag",
+	names: map[int][]string{
+		int(NotInternal): {"Not internal"},
+		int(Internal):    {"Internal"},
+	},
+}
+
+func (t InternalType) String() string {
+	return internalTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t InternalType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *InternalType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseInternalType(string(text))
+	return
+}
+
+// ParseInternalType Parses an internal flag from its display name or number.
+func ParseInternalType(s string) (InternalType, error) {
+	v, err := internalTypeNames.parse(s)
+	return InternalType(v), err
+}
+
 // HostGroup represent Zabbix host group object
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/hostgroup/object
 type HostGroup struct {
 	GroupID  string       `json:"groupid,omitempty"`
 	Name     string       `json:"name"`
-	Internal InternalType `json:"internal,omitempty,string"`
+	Internal InternalType `json:"internal,omitempty"`
 }
 
 // HostGroups is an array of HostGroup
@@ -66,10 +105,8 @@ func (api *API) HostGroupsCreate(hostGroups HostGroups) (err error) {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	groupids := result["groupids"].([]interface{})
-	for i, id := range groupids {
-		hostGroups[i].GroupID = id.(string)
+	for i, id := range resultIDs(response.Result, "groupids") {
+		hostGroups[i].GroupID = id
 	}
 	return
 }
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 )
 
-
 type (
 	// InterfaceType different interface type
 	InterfaceType int
@@ -24,17 +23,58 @@ const (
 	JMX InterfaceType = 4
 )
 
+// MarshalJSON encodes t as a string.
+func (t InterfaceType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *InterfaceType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var interfaceTypeNames = enumNames{
+	kind: "interface type",
+	names: map[int][]string{
+		int(Agent): {"Agent", "Zabbix agent"},
+		int(SNMP):  {"SNMP"},
+		int(IPMI):  {"IPMI"},
+		int(JMX):   {"JMX"},
+	},
+}
+
+func (t InterfaceType) String() string {
+	return interfaceTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t InterfaceType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *InterfaceType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseInterfaceType(string(text))
+	return
+}
+
+// ParseInterfaceType Parses an interface type from its display name or number.
+func ParseInterfaceType(s string) (InterfaceType, error) {
+	v, err := interfaceTypeNames.parse(s)
+	return InterfaceType(v), err
+}
+
 type InterfaceDetails struct {
-	Version        int    `json:"version,string,omitempty"`
-	Bulk           int    `json:"bulk,string,omitempty"`
+	Version        Int    `json:"version,omitempty"`
+	Bulk           Int    `json:"bulk,omitempty"`
 	Community      string `json:"community,omitempty"`
-	MaxRepetitions int    `json:"max_repetitions,string,omitempty"`
+	MaxRepetitions Int    `json:"max_repetitions,omitempty"`
 	SecurityName   string `json:"securityname,omitempty"`
-	SecurityLevel  int    `json:"securitylevel,string,omitempty"`
+	SecurityLevel  Int    `json:"securitylevel,omitempty"`
 	AuthPassphrase string `json:"authpassphrase,omitempty"`
 	PrivPassphrase string `json:"privpassphrase,omitempty"`
-	AuthProtocol   int    `json:"authprotocol,string,omitempty"`
-	PrivProtocol   int    `json:"privprotocol,string,omitempty"`
+	AuthProtocol   Int    `json:"authprotocol,omitempty"`
+	PrivProtocol   Int    `json:"privprotocol,omitempty"`
 	ContextName    string `json:"contextname,omitempty"`
 }
 
@@ -44,10 +84,10 @@ type HostInterface struct {
 	InterfaceID string           `json:"interfaceid,omitempty"`
 	DNS         string           `json:"dns"`
 	IP          string           `json:"ip"`
-	Main        int              `json:"main,string"`
+	Main        Int              `json:"main"`
 	Port        string           `json:"port"`
-	Type        InterfaceType    `json:"type,string"`
-	UseIP       int              `json:"useip,string"`
+	Type        InterfaceType    `json:"type"`
+	UseIP       Int              `json:"useip"`
 	Details     InterfaceDetails `json:"details,omitempty"`
 }
 
@@ -64,14 +104,14 @@ func (h *HostInterface) UnmarshalJSON(data []byte) error {
 		Alias: (*Alias)(h),
 	}
 
-	if err := json.Unmarshal(data, &aux); err != nil {
+	if err := unmarshalFlexible(data, &aux); err != nil {
 		return err
 	}
 
 	// Handle the details field based on its type
 	if string(aux.Details) != "[]" {
 		var details InterfaceDetails
-		if err := json.Unmarshal(aux.Details, &details); err != nil {
+		if err := unmarshalFlexible(aux.Details, &details); err != nil {
 			return err
 		}
 		h.Details = details
@@ -1,9 +1,12 @@
 package zabbix_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	zapi "github.com/claranet/go-zabbix-api"
@@ -178,6 +181,7 @@ func TestHosts(t *testing.T) {
 		t.Errorf("Bad userMacros: %#v", hosts[0].UserMacros)
 	}
 	macro2 := hosts[0].UserMacros[0]
+	macro.MacroID = macro2.MacroID
 	macro.HostID = hosts[0].HostID
 	if !reflect.DeepEqual(macro, macro2) {
 		t.Errorf("UserMacros are not equal:\n%#v\n%#v", macro, macro2)
@@ -193,3 +197,36 @@ func TestHosts(t *testing.T) {
 		t.Errorf("Bad hosts: %#v", hosts)
 	}
 }
+
+func TestHostsDeleteByIdsSyntax(t *testing.T) {
+	cases := []struct {
+		version string // reported by the server
+		unknown bool   // ServerVersion left unknown, as when NewAPI cannot parse it
+		calls   []string
+	}{
+		{"2.0.0", false, []string{`[{"hostid":"1"}]`}},
+		{"5.0.0", false, []string{`["1"]`}},
+		{"5.0.0", true, []string{`["1"]`}},
+		{"2.0.0", true, []string{`["1"]`, `[{"hostid":"1"}]`}},
+	}
+	for _, c := range cases {
+		var calls []string
+		old := c.version == "2.0.0"
+		api := testFakeAPI(t, c.version, func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+			calls = append(calls, string(params))
+			if old && !strings.HasPrefix(string(params), "[{") {
+				return "", &zapi.Error{Code: -32500, Message: "Application error."}
+			}
+			return `{"hostids":["1"]}`, nil
+		})
+		if c.unknown {
+			api.ServerVersion = nil
+		}
+		if err := api.HostsDeleteByIds([]string{"1"}); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(calls, c.calls) {
+			t.Errorf("Zabbix %s (unknown %t): unexpected host.delete calls %v", c.version, c.unknown, calls)
+		}
+	}
+}
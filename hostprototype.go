@@ -0,0 +1,265 @@
+package zabbix
+
+import "encoding/json"
+
+type (
+	// InventoryMode Host inventory population mode
+	// see "inventory_mode" in https://www.zabbix.com/documentation/6.0/manual/api/reference/hostprototype/object
+	InventoryMode int
+)
+
+const (
+	// InventoryDisabled (default) inventory disabled
+	InventoryDisabled InventoryMode = -1
+	// InventoryManual inventory filled by hand
+	InventoryManual InventoryMode = 0
+	// InventoryAutomatic inventory filled from item values
+	InventoryAutomatic InventoryMode = 1
+)
+
+// MarshalJSON encodes t as a string.
+func (t InventoryMode) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *InventoryMode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var inventoryModeNames = enumNames{
+	kind: "inventory mode",
+	names: map[int][]string{
+		int(InventoryDisabled):  {"Disabled"},
+		int(InventoryManual):    {"Manual"},
+		int(InventoryAutomatic): {"Automatic"},
+	},
+}
+
+func (t InventoryMode) String() string {
+	return inventoryModeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t InventoryMode) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *InventoryMode) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseInventoryMode(string(text))
+	return
+}
+
+// ParseInventoryMode Parses an inventory mode from its display name or number.
+func ParseInventoryMode(s string) (InventoryMode, error) {
+	v, err := inventoryModeNames.parse(s)
+	return InventoryMode(v), err
+}
+
+// HostPrototypeGroupLink represent Zabbix group link object, an existing host group of the discovered hosts
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/hostprototype/object#group-link
+type HostPrototypeGroupLink struct {
+	GroupID string `json:"groupid"`
+}
+
+// HostPrototypeGroupLinks is an array of HostPrototypeGroupLink
+type HostPrototypeGroupLinks []HostPrototypeGroupLink
+
+// HostPrototypeGroupPrototype represent Zabbix group prototype object, a host group created for the discovered hosts
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/hostprototype/object#group-prototype
+type HostPrototypeGroupPrototype struct {
+	Name string `json:"name"` // with LLD macros, such as "{#VM.GROUP}"
+}
+
+// HostPrototypeGroupPrototypes is an array of HostPrototypeGroupPrototype
+type HostPrototypeGroupPrototypes []HostPrototypeGroupPrototype
+
+// HostPrototype represent Zabbix host prototype object
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/hostprototype/object
+type HostPrototype struct {
+	HostID     string     `json:"hostid,omitempty"`     // Readonly
+	Host       string     `json:"host"`                 // Required, with LLD macros
+	Name       string     `json:"name,omitempty"`       // visible name, with LLD macros
+	TemplateID string     `json:"templateid,omitempty"` // Readonly
+	Status     StatusType `json:"status"`
+	Discover   Int        `json:"discover,omitempty"` // 1 to stop discovering hosts from the prototype
+
+	// InventoryMode is only sent when set, the server disabling the inventory by default.
+	// NOTE: inventory object before 4.4
+	InventoryMode *InventoryMode `json:"inventory_mode,omitempty"`
+
+	// RuleID is the LLD rule the prototype belongs to, required on creation only.
+	RuleID string `json:"ruleid,omitempty"`
+
+	// Groups are required on creation, group prototypes are optional.
+	GroupLinks      HostPrototypeGroupLinks      `json:"groupLinks,omitempty"`
+	GroupPrototypes HostPrototypeGroupPrototypes `json:"groupPrototypes,omitempty"`
+	Templates       TemplateIDs                  `json:"templates,omitempty"`
+	UserMacros      Macros                       `json:"macros,omitempty"`
+	Tags            Tags                         `json:"tags,omitempty"`
+
+	// CustomInterfaces 1 to give the discovered hosts the interfaces below instead of those of their parent host.
+	// NOTE: new in 6.0
+	CustomInterfaces Int            `json:"custom_interfaces,omitempty"`
+	Interfaces       HostInterfaces `json:"interfaces,omitempty"`
+
+	// DiscoveryRule is returned with selectDiscoveryRule.
+	DiscoveryRule *LLDRule `json:"discoveryRule,omitempty"`
+}
+
+// HostPrototypes is an array of HostPrototype
+type HostPrototypes []HostPrototype
+
+var hostPrototypeFields = fieldRules{
+	{Field: "inventory_mode", Feature: FeatureInventoryMode, Legacy: "inventory",
+		Convert: func(value interface{}) interface{} {
+			return map[string]interface{}{"inventory_mode": value}
+		},
+		Restore: func(value interface{}) interface{} {
+			if inventory, ok := value.(map[string]interface{}); ok {
+				return inventory["inventory_mode"]
+			}
+			// no inventory object means inventory disabled
+			return InventoryDisabled
+		},
+	},
+	{Field: "tags", Feature: FeatureTags},
+	{Field: "custom_interfaces", Feature: FeatureCustomInterfaces},
+	{Field: "interfaces", Feature: FeatureCustomInterfaces},
+}
+
+// MarshalJSON omits the read-only parent template and LLD rule object, so that fetched host prototypes can be updated.
+func (p HostPrototype) MarshalJSON() ([]byte, error) {
+	type hostPrototype HostPrototype
+	p.TemplateID = ""
+	p.DiscoveryRule = nil
+	return json.Marshal(hostPrototype(p))
+}
+
+// UnmarshalJSON decodes the inventory mode of the host prototype whatever the server version.
+func (p *HostPrototype) UnmarshalJSON(data []byte) (err error) {
+	if data, err = hostPrototypeFields.canonical(data); err != nil {
+		return
+	}
+	type hostPrototype HostPrototype
+	return unmarshalFlexible(data, (*hostPrototype)(p))
+}
+
+// selectHostPrototype returns the parameters selecting the links of host prototypes supported by the server.
+func (api *API) selectHostPrototype(params Params) Params {
+	params["selectGroupLinks"] = "extend"
+	params["selectGroupPrototypes"] = "extend"
+	params["selectTemplates"] = "extend"
+	params["selectMacros"] = "extend"
+	if api.Supports(FeatureTags) {
+		params["selectTags"] = "extend"
+	}
+	if api.Supports(FeatureCustomInterfaces) {
+		params["selectInterfaces"] = "extend"
+	}
+	if !api.Supports(FeatureInventoryMode) {
+		params["selectInventory"] = []string{"inventory_mode"}
+	}
+	return params
+}
+
+// HostPrototypesGet Wrapper for hostprototype.get
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/hostprototype/get
+func (api *API) HostPrototypesGet(params Params) (res HostPrototypes, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("hostprototype.get", params, &res)
+	return
+}
+
+// HostPrototypesGetByRuleID Gets the host prototypes of an LLD rule, with their groups, templates, macros, tags and interfaces.
+func (api *API) HostPrototypesGetByRuleID(ruleID string) (res HostPrototypes, err error) {
+	return api.HostPrototypesGet(api.selectHostPrototype(Params{"discoveryids": ruleID}))
+}
+
+// HostPrototypeGetByID Gets host prototype by Id only if there is exactly 1 matching host prototype.
+// Its groups, templates, macros, tags and interfaces are selected.
+func (api *API) HostPrototypeGetByID(id string) (res *HostPrototype, err error) {
+	prototypes, err := api.HostPrototypesGet(api.selectHostPrototype(Params{"hostids": id}))
+	if err != nil {
+		return
+	}
+
+	if len(prototypes) == 1 {
+		res = &prototypes[0]
+	} else {
+		e := ExpectedOneResult(len(prototypes))
+		err = &e
+	}
+	return
+}
+
+// HostPrototypesCreate Wrapper for hostprototype.create
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/hostprototype/create
+func (api *API) HostPrototypesCreate(prototypes HostPrototypes) (err error) {
+	params, err := api.marshalFor(prototypes, hostPrototypeFields)
+	if err != nil {
+		return
+	}
+	if err = api.adaptMacros(params); err != nil {
+		return
+	}
+	response, err := api.CallWithError("hostprototype.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "hostids") {
+		prototypes[i].HostID = id
+	}
+	return
+}
+
+// HostPrototypesUpdate Wrapper for hostprototype.update
+// RuleID must be empty, the LLD rule of a host prototype cannot change.
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/hostprototype/update
+func (api *API) HostPrototypesUpdate(prototypes HostPrototypes) (err error) {
+	params, err := api.marshalFor(prototypes, hostPrototypeFields)
+	if err != nil {
+		return
+	}
+	if err = api.adaptMacros(params); err != nil {
+		return
+	}
+	_, err = api.CallWithError("hostprototype.update", params)
+	return
+}
+
+// HostPrototypesDelete Wrapper for hostprototype.delete
+// Cleans HostID in all host prototypes elements if call succeed.
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/hostprototype/delete
+func (api *API) HostPrototypesDelete(prototypes HostPrototypes) (err error) {
+	ids := make([]string, len(prototypes))
+	for i, prototype := range prototypes {
+		ids[i] = prototype.HostID
+	}
+
+	err = api.HostPrototypesDeleteByIds(ids)
+	if err == nil {
+		for i := range prototypes {
+			prototypes[i].HostID = ""
+		}
+	}
+	return
+}
+
+// HostPrototypesDeleteByIds Wrapper for hostprototype.delete
+// https://www.zabbix.com/documentation/6.0/manual/api/reference/hostprototype/delete
+func (api *API) HostPrototypesDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("hostprototype.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "hostids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
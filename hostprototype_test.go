@@ -0,0 +1,131 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestHostPrototypeLegacyInventory(t *testing.T) {
+	var prototype zapi.HostPrototype
+	err := json.Unmarshal([]byte(`{"hostid":"1","host":"{#VM.NAME}","inventory":{"inventory_mode":"1"}}`), &prototype)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prototype.InventoryMode == nil || *prototype.InventoryMode != zapi.InventoryAutomatic {
+		t.Errorf("Unexpected inventory mode: %#v", prototype)
+	}
+
+	prototype = zapi.HostPrototype{}
+	if err = json.Unmarshal([]byte(`{"hostid":"1","host":"{#VM.NAME}","inventory":[]}`), &prototype); err != nil {
+		t.Fatal(err)
+	}
+	if prototype.InventoryMode == nil || *prototype.InventoryMode != zapi.InventoryDisabled {
+		t.Errorf("Unexpected inventory mode without inventory: %#v", prototype)
+	}
+
+	b, err := json.Marshal(zapi.HostPrototype{Host: "{#VM.NAME}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "inventory_mode") {
+		t.Errorf("Unset inventory mode should not be sent: %s", b)
+	}
+}
+
+func TestHostPrototypes(t *testing.T) {
+	api := testGetAPI(t)
+
+	// Zabbix v6.2 introduced Template Groups and requires them for Templates
+	var groupIds zapi.HostGroupIDs
+	if compLessThan, _ := isVersionLessThan(t, "6.2"); compLessThan {
+		hostGroup := testCreateHostGroup(t)
+		defer testDeleteHostGroup(hostGroup, t)
+		groupIds = zapi.HostGroupIDs{{GroupID: hostGroup.GroupID}}
+	} else {
+		templateGroup := testCreateTemplateGroup(t)
+		defer testDeleteTemplateGroup(templateGroup, t)
+		groupIds = zapi.HostGroupIDs{{GroupID: templateGroup.GroupID}}
+	}
+
+	template := testCreateTemplate(&groupIds, t)
+	defer testDeleteTemplate(template, t)
+
+	lldRule := testCreateLLDRule(template, t)
+	defer testDeleteLLDRule(lldRule, t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	automatic, disabled := zapi.InventoryAutomatic, zapi.InventoryDisabled
+	prototypes := zapi.HostPrototypes{{
+		Host:            "{#VM.NAME}",
+		Name:            fmt.Sprintf("zabbix-testing-%d {#VM.NAME}", rand.Int()),
+		Status:          zapi.Disabled,
+		InventoryMode:   &automatic,
+		RuleID:          lldRule.ItemID,
+		GroupLinks:      zapi.HostPrototypeGroupLinks{{GroupID: group.GroupID}},
+		GroupPrototypes: zapi.HostPrototypeGroupPrototypes{{Name: "VMs/{#VM.CLUSTER}"}},
+		UserMacros:      zapi.Macros{{MacroName: "{$VM.ROLE}", Value: "{#VM.ROLE}"}},
+	}}
+	if api.Supports(zapi.FeatureTags) {
+		prototypes[0].Tags = zapi.Tags{{Tag: "cluster", Value: "{#VM.CLUSTER}"}}
+	}
+	if api.Supports(zapi.FeatureCustomInterfaces) {
+		prototypes[0].CustomInterfaces = 1
+		prototypes[0].Interfaces = zapi.HostInterfaces{{DNS: "{#VM.DNS}", Main: 1, Port: "10050", Type: zapi.Agent}}
+	}
+	err := api.HostPrototypesCreate(prototypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prototype := &prototypes[0]
+	if prototype.HostID == "" {
+		t.Errorf("Host prototype ID is empty: %#v", prototype)
+	}
+
+	prototype2, err := api.HostPrototypeGetByID(prototype.HostID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prototype2.Name != prototype.Name || prototype2.Status != zapi.Disabled || prototype2.InventoryMode == nil || *prototype2.InventoryMode != zapi.InventoryAutomatic {
+		t.Fatalf("Host prototypes are not equal:\n%#v\n%#v", prototype, prototype2)
+	}
+	if !reflect.DeepEqual(prototype2.GroupLinks, prototype.GroupLinks) || !reflect.DeepEqual(prototype2.GroupPrototypes, prototype.GroupPrototypes) {
+		t.Errorf("Unexpected groups: %#v", prototype2)
+	}
+	if !reflect.DeepEqual(prototype2.Tags, prototype.Tags) || len(prototype2.UserMacros) != 1 || len(prototype2.Interfaces) != len(prototype.Interfaces) {
+		t.Errorf("Unexpected tags, macros or interfaces: %#v", prototype2)
+	}
+
+	prototypes2, err := api.HostPrototypesGetByRuleID(lldRule.ItemID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prototypes2) != 1 || prototypes2[0].HostID != prototype.HostID {
+		t.Errorf("Unexpected host prototypes of the LLD rule: %#v", prototypes2)
+	}
+
+	err = api.HostPrototypesUpdate(zapi.HostPrototypes{{
+		HostID:        prototype.HostID,
+		Host:          prototype.Host,
+		Name:          "{#VM.NAME} updated",
+		InventoryMode: &disabled,
+	}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = api.HostPrototypesDelete(prototypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prototype.HostID != "" {
+		t.Errorf("Host prototype ID is not cleaned: %#v", prototype)
+	}
+}
@@ -0,0 +1,459 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+type (
+	// HTTPAuthType Authentication method of a web scenario
+	// see "authentication" in https://www.zabbix.com/documentation/5.0/manual/api/reference/httptest/object
+	HTTPAuthType int
+
+	// HTTPRetrieveMode Part of the response retrieved by a web scenario step
+	// see "retrieve_mode" in https://www.zabbix.com/documentation/5.0/manual/api/reference/httptest/object#scenario-step
+	HTTPRetrieveMode int
+)
+
+const (
+	// HTTPAuthNone (default) no authentication
+	HTTPAuthNone HTTPAuthType = 0
+	// HTTPAuthBasic basic authentication
+	HTTPAuthBasic HTTPAuthType = 1
+	// HTTPAuthNTLM NTLM authentication
+	HTTPAuthNTLM HTTPAuthType = 2
+	// HTTPAuthKerberos Kerberos authentication
+	HTTPAuthKerberos HTTPAuthType = 3
+	// HTTPAuthDigest digest authentication
+	HTTPAuthDigest HTTPAuthType = 4
+)
+
+const (
+	// RetrieveBody (default) body only
+	RetrieveBody HTTPRetrieveMode = 0
+	// RetrieveHeaders headers only
+	RetrieveHeaders HTTPRetrieveMode = 1
+	// RetrieveBoth body and headers
+	RetrieveBoth HTTPRetrieveMode = 2
+)
+
+// MarshalJSON encodes t as a string.
+func (t HTTPAuthType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *HTTPAuthType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var httpAuthTypeNames = enumNames{
+	kind: "HTTP authentication",
+	names: map[int][]string{
+		int(HTTPAuthNone):     {"None"},
+		int(HTTPAuthBasic):    {"Basic"},
+		int(HTTPAuthNTLM):     {"NTLM"},
+		int(HTTPAuthKerberos): {"Kerberos"},
+		int(HTTPAuthDigest):   {"Digest"},
+	},
+}
+
+func (t HTTPAuthType) String() string {
+	return httpAuthTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t HTTPAuthType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *HTTPAuthType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseHTTPAuthType(string(text))
+	return
+}
+
+// ParseHTTPAuthType Parses an HTTP authentication from its display name or number.
+func ParseHTTPAuthType(s string) (HTTPAuthType, error) {
+	v, err := httpAuthTypeNames.parse(s)
+	return HTTPAuthType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t HTTPRetrieveMode) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *HTTPRetrieveMode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var httpRetrieveModeNames = enumNames{
+	kind: "retrieve mode",
+	names: map[int][]string{
+		int(RetrieveBody):    {"Body"},
+		int(RetrieveHeaders): {"Headers"},
+		int(RetrieveBoth):    {"Body and headers"},
+	},
+}
+
+func (t HTTPRetrieveMode) String() string {
+	return httpRetrieveModeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t HTTPRetrieveMode) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *HTTPRetrieveMode) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseHTTPRetrieveMode(string(text))
+	return
+}
+
+// ParseHTTPRetrieveMode Parses a retrieve mode from its display name or number.
+func ParseHTTPRetrieveMode(s string) (HTTPRetrieveMode, error) {
+	v, err := httpRetrieveModeNames.parse(s)
+	return HTTPRetrieveMode(v), err
+}
+
+// HTTPField is a name and value pair of a web scenario, such as a header, a variable, a query field or a form field.
+// Variable names include their braces, such as "{token}".
+type HTTPField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HTTPFields is an array of HTTPField
+type HTTPFields []HTTPField
+
+// UnmarshalJSON accepts arrays of fields and, before 4.0, texts of "Name: value" header or "{name}=value" variable lines.
+func (f *HTTPFields) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || data[0] != '"' {
+		type httpFields HTTPFields
+		return unmarshalFlexible(data, (*httpFields)(f))
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	*f = nil
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if i := strings.Index(line, "}="); strings.HasPrefix(line, "{") && i > 0 {
+			*f = append(*f, HTTPField{Name: line[:i+1], Value: line[i+2:]})
+			continue
+		}
+		field := HTTPField{Name: strings.TrimSpace(line)}
+		if i := strings.Index(line, ":"); i >= 0 {
+			field = HTTPField{Name: strings.TrimSpace(line[:i]), Value: strings.TrimSpace(line[i+1:])}
+		}
+		*f = append(*f, field)
+	}
+	return nil
+}
+
+// HTTPStep represent Zabbix web scenario step object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/httptest/object#scenario-step
+type HTTPStep struct {
+	StepID      string     `json:"httpstepid,omitempty"` // Readonly
+	Name        string     `json:"name"`                 // Required
+	No          Int        `json:"no"`                   // Required, sequence number of the step
+	URL         string     `json:"url"`                  // Required
+	QueryFields HTTPFields `json:"query_fields,omitempty"`
+	// Posts is a raw request body, PostFields are form fields, only one of them may be set.
+	Posts      string     `json:"-"`
+	PostFields HTTPFields `json:"-"`
+	Variables  HTTPFields `json:"variables,omitempty"`
+	Headers    HTTPFields `json:"headers,omitempty"`
+
+	FollowRedirects *Int             `json:"follow_redirects,omitempty"` // 0 to not follow redirects, 1 by default
+	RetrieveMode    HTTPRetrieveMode `json:"retrieve_mode,omitempty"`
+	Timeout         string           `json:"timeout,omitempty"`      // such as "15s"
+	Required        string           `json:"required,omitempty"`     // text required in the response
+	StatusCodes     string           `json:"status_codes,omitempty"` // such as "200,201,210-299"
+}
+
+// HTTPSteps is an array of HTTPStep
+type HTTPSteps []HTTPStep
+
+// MarshalJSON encodes Posts or PostFields as posts, with the matching post_type.
+func (s HTTPStep) MarshalJSON() ([]byte, error) {
+	type httpStep HTTPStep
+	step := struct {
+		httpStep
+		PostsValue interface{} `json:"posts,omitempty"`
+		PostType   *Int        `json:"post_type,omitempty"`
+	}{httpStep: httpStep(s)}
+	if s.PostFields != nil {
+		form := Int(0)
+		step.PostsValue, step.PostType = s.PostFields, &form
+	} else if s.Posts != "" {
+		raw := Int(1)
+		step.PostsValue, step.PostType = s.Posts, &raw
+	}
+	return json.Marshal(step)
+}
+
+// UnmarshalJSON decodes posts as Posts or PostFields.
+func (s *HTTPStep) UnmarshalJSON(data []byte) (err error) {
+	type httpStep HTTPStep
+	step := struct {
+		*httpStep
+		PostsValue json.RawMessage `json:"posts"`
+	}{httpStep: (*httpStep)(s)}
+	if err = unmarshalFlexible(data, &step); err != nil {
+		return
+	}
+	s.Posts, s.PostFields = "", nil
+	if len(step.PostsValue) > 0 && step.PostsValue[0] == '[' {
+		err = unmarshalFlexible(step.PostsValue, &s.PostFields)
+	} else if len(step.PostsValue) > 0 {
+		s.Posts, err = scalarString(step.PostsValue)
+	}
+	return
+}
+
+// HTTPTest represent Zabbix web scenario object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/httptest/object
+type HTTPTest struct {
+	HTTPTestID string     `json:"httptestid,omitempty"` // Readonly
+	Name       string     `json:"name"`                 // Required
+	HostID     string     `json:"hostid,omitempty"`     // Required on creation
+	Delay      string     `json:"delay,omitempty"`      // such as "1m"
+	Retries    Int        `json:"retries,omitempty"`
+	Agent      string     `json:"agent,omitempty"`
+	HTTPProxy  string     `json:"http_proxy,omitempty"`
+	Status     StatusType `json:"status"`
+	Variables  HTTPFields `json:"variables,omitempty"`
+	Headers    HTTPFields `json:"headers,omitempty"`
+
+	Authentication HTTPAuthType `json:"authentication,omitempty"`
+	HTTPUser       string       `json:"http_user,omitempty"`
+	HTTPPassword   string       `json:"http_password,omitempty"`
+	VerifyPeer     Int          `json:"verify_peer,omitempty"`
+	VerifyHost     Int          `json:"verify_host,omitempty"`
+	SSLCertFile    string       `json:"ssl_cert_file,omitempty"`
+	SSLKeyFile     string       `json:"ssl_key_file,omitempty"`
+	SSLKeyPassword string       `json:"ssl_key_password,omitempty"`
+
+	// NOTE: removed in 5.4
+	ApplicationID string `json:"applicationid,omitempty"`
+	// NOTE: new in 5.4
+	Tags Tags `json:"tags,omitempty"`
+
+	// Steps are required on creation and returned with selectSteps.
+	Steps HTTPSteps `json:"steps,omitempty"`
+}
+
+// HTTPTests is an array of HTTPTest
+type HTTPTests []HTTPTest
+
+var httpTestFields = fieldRules{
+	{Field: "applicationid", Feature: FeatureApplications},
+	{Field: "tags", Feature: FeatureItemTags},
+}
+
+// httpTestParams returns the parameters of httptest.create and httptest.update for the server version.
+// Before 4.0 headers and variables are texts, query fields are added to the URL and form fields are encoded.
+func (api *API) httpTestParams(tests HTTPTests) (params interface{}, err error) {
+	if params, err = api.marshalFor(tests, httpTestFields); err != nil || !api.legacy(FeatureHTTPFields) {
+		return
+	}
+	for _, o := range params.([]interface{}) {
+		test := o.(map[string]interface{})
+		legacyHTTPFields(test)
+		steps, _ := test["steps"].([]interface{})
+		for _, s := range steps {
+			step := s.(map[string]interface{})
+			legacyHTTPFields(step)
+			if fields, ok := step["query_fields"].([]interface{}); ok {
+				if u, _ := step["url"].(string); strings.Contains(u, "?") {
+					step["url"] = u + "&" + encodeHTTPFields(fields)
+				} else {
+					step["url"] = u + "?" + encodeHTTPFields(fields)
+				}
+				delete(step, "query_fields")
+			}
+			if fields, ok := step["posts"].([]interface{}); ok {
+				step["posts"] = encodeHTTPFields(fields)
+			}
+			delete(step, "post_type")
+		}
+	}
+	return
+}
+
+// legacyHTTPFields converts the headers and variables of a web scenario or step to texts.
+func legacyHTTPFields(object map[string]interface{}) {
+	for key, separator := range map[string]string{"headers": ": ", "variables": "="} {
+		fields, ok := object[key].([]interface{})
+		if !ok {
+			continue
+		}
+		lines := make([]string, 0, len(fields))
+		for _, f := range fields {
+			field, _ := f.(map[string]interface{})
+			lines = append(lines, field["name"].(string)+separator+field["value"].(string))
+		}
+		object[key] = strings.Join(lines, "\n")
+	}
+}
+
+// encodeHTTPFields encodes fields as a query string, keeping their order.
+func encodeHTTPFields(fields []interface{}) string {
+	pairs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		field, _ := f.(map[string]interface{})
+		pairs = append(pairs, url.QueryEscape(field["name"].(string))+"="+url.QueryEscape(field["value"].(string)))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// HTTPTestsGet Wrapper for httptest.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/httptest/get
+func (api *API) HTTPTestsGet(params Params) (res HTTPTests, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("httptest.get", params, &res)
+	return
+}
+
+// HTTPTestGetByID Gets web scenario by Id only if there is exactly 1 matching web scenario.
+// Its steps and tags are selected.
+func (api *API) HTTPTestGetByID(id string) (res *HTTPTest, err error) {
+	params := Params{"httptestids": id, "selectSteps": "extend"}
+	if api.Supports(FeatureItemTags) {
+		params["selectTags"] = "extend"
+	}
+	tests, err := api.HTTPTestsGet(params)
+	if err != nil {
+		return
+	}
+
+	if len(tests) == 1 {
+		res = &tests[0]
+	} else {
+		e := ExpectedOneResult(len(tests))
+		err = &e
+	}
+	return
+}
+
+// HTTPTestsCreate Wrapper for httptest.create
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/httptest/create
+func (api *API) HTTPTestsCreate(tests HTTPTests) (err error) {
+	params, err := api.httpTestParams(tests)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("httptest.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "httptestids") {
+		tests[i].HTTPTestID = id
+	}
+	return
+}
+
+// HTTPTestsUpdate Wrapper for httptest.update
+// Steps given replace the steps of the web scenario.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/httptest/update
+func (api *API) HTTPTestsUpdate(tests HTTPTests) (err error) {
+	params, err := api.httpTestParams(tests)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("httptest.update", params)
+	return
+}
+
+// HTTPTestsDelete Wrapper for httptest.delete
+// Cleans HTTPTestID in all web scenarios elements if call succeed.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/httptest/delete
+func (api *API) HTTPTestsDelete(tests HTTPTests) (err error) {
+	ids := make([]string, len(tests))
+	for i, test := range tests {
+		ids[i] = test.HTTPTestID
+	}
+
+	err = api.HTTPTestsDeleteByIds(ids)
+	if err == nil {
+		for i := range tests {
+			tests[i].HTTPTestID = ""
+		}
+	}
+	return
+}
+
+// HTTPTestsDeleteByIds Wrapper for httptest.delete
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/httptest/delete
+func (api *API) HTTPTestsDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("httptest.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "httptestids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// HTTPTestItems Gets the items created by the server for the web scenario and its steps,
+// such as web.test.fail[scenario] or web.test.time[scenario,step,resp].
+// The web scenario must have its name and host.
+func (api *API) HTTPTestItems(test HTTPTest) (res Items, err error) {
+	items, err := api.ItemsGet(Params{
+		"hostids":     test.HostID,
+		"webitems":    true,
+		"search":      map[string]string{"key_": "web.test."},
+		"startSearch": true,
+	})
+	if err != nil {
+		return
+	}
+	for _, item := range items {
+		if firstKeyParam(item.Key) == test.Name {
+			res = append(res, item)
+		}
+	}
+	return
+}
+
+// firstKeyParam returns the first parameter of an item key, unquoted.
+func firstKeyParam(key string) string {
+	i := strings.IndexByte(key, '[')
+	if i < 0 {
+		return ""
+	}
+	s := strings.TrimLeft(key[i+1:], " ")
+	if !strings.HasPrefix(s, `"`) {
+		if j := strings.IndexAny(s, ",]"); j >= 0 {
+			return s[:j]
+		}
+		return s
+	}
+	var b strings.Builder
+	for j := 1; j < len(s); j++ {
+		switch {
+		case s[j] == '\\' && j+1 < len(s) && s[j+1] == '"':
+			b.WriteByte('"')
+			j++
+		case s[j] == '"':
+			return b.String()
+		default:
+			b.WriteByte(s[j])
+		}
+	}
+	return b.String()
+}
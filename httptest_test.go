@@ -0,0 +1,143 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestHTTPStepEncoding(t *testing.T) {
+	form := zapi.HTTPStep{Name: "login", PostFields: zapi.HTTPFields{{Name: "user", Value: "admin"}}}
+	b, err := json.Marshal(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"posts":[{"name":"user","value":"admin"}]`) || !strings.Contains(string(b), `"post_type":"0"`) {
+		t.Errorf("Unexpected form step: %s", b)
+	}
+	var step zapi.HTTPStep
+	if err = json.Unmarshal(b, &step); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(step.PostFields, form.PostFields) || step.Posts != "" {
+		t.Errorf("Unexpected decoded form step: %#v", step)
+	}
+
+	raw := zapi.HTTPStep{Name: "api", Posts: `{"a":1}`}
+	if b, err = json.Marshal(raw); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"post_type":"1"`) {
+		t.Errorf("Unexpected raw step: %s", b)
+	}
+	if err = json.Unmarshal(b, &step); err != nil {
+		t.Fatal(err)
+	}
+	if step.Posts != raw.Posts || step.PostFields != nil {
+		t.Errorf("Unexpected decoded raw step: %#v", step)
+	}
+
+	// headers and variables before 4.0
+	err = json.Unmarshal([]byte(`{"name":"old","headers":"Accept: text/html\r\nX-Empty:\n","variables":"{token}=a:b\n"}`), &step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headers := zapi.HTTPFields{{Name: "Accept", Value: "text/html"}, {Name: "X-Empty"}}
+	variables := zapi.HTTPFields{{Name: "{token}", Value: "a:b"}}
+	if !reflect.DeepEqual(step.Headers, headers) || !reflect.DeepEqual(step.Variables, variables) {
+		t.Errorf("Unexpected legacy fields: %#v", step)
+	}
+}
+
+func TestHTTPTests(t *testing.T) {
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	noRedirects := zapi.Int(0)
+	tests := zapi.HTTPTests{{
+		Name:    fmt.Sprintf("zabbix-testing-%d", rand.Int()),
+		HostID:  host.HostID,
+		Delay:   "5m",
+		Status:  zapi.Disabled,
+		Headers: zapi.HTTPFields{{Name: "Accept", Value: "text/html"}},
+		Steps: zapi.HTTPSteps{
+			{
+				Name:        "home",
+				No:          1,
+				URL:         "http://localhost/",
+				QueryFields: zapi.HTTPFields{{Name: "lang", Value: "en"}},
+				Required:    "Welcome",
+				StatusCodes: "200",
+			},
+			{
+				Name:            "login",
+				No:              2,
+				URL:             "http://localhost/login",
+				PostFields:      zapi.HTTPFields{{Name: "user", Value: "admin"}},
+				FollowRedirects: &noRedirects,
+				Timeout:         "10s",
+			},
+		},
+	}}
+	if api.Supports(zapi.FeatureItemTags) {
+		tests[0].Tags = zapi.Tags{{Tag: "team", Value: "web"}}
+	}
+	err := api.HTTPTestsCreate(tests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test := &tests[0]
+	if test.HTTPTestID == "" {
+		t.Errorf("Web scenario ID is empty: %#v", test)
+	}
+
+	test2, err := api.HTTPTestGetByID(test.HTTPTestID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if test2.Name != test.Name || test2.Status != zapi.Disabled || len(test2.Steps) != 2 || !reflect.DeepEqual(test2.Tags, test.Tags) {
+		t.Fatalf("Web scenarios are not equal:\n%#v\n%#v", test, test2)
+	}
+	if api.Supports(zapi.FeatureHTTPFields) {
+		if !reflect.DeepEqual(test2.Headers, test.Headers) || !reflect.DeepEqual(test2.Steps[1].PostFields, test.Steps[1].PostFields) {
+			t.Errorf("Unexpected fields: %#v", test2)
+		}
+	}
+	if test2.Steps[1].FollowRedirects == nil || *test2.Steps[1].FollowRedirects != 0 || test2.Steps[0].Required != "Welcome" {
+		t.Errorf("Unexpected steps: %#v", test2.Steps)
+	}
+
+	items, err := api.HTTPTestItems(*test2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// download speed, failed step and last error of the scenario, speed, time and response code of each step
+	if len(items) != 9 {
+		t.Errorf("Expected 9 web scenario items, got %d", len(items))
+	}
+
+	test2.HostID = ""
+	test2.Steps = test2.Steps[:1]
+	err = api.HTTPTestsUpdate(zapi.HTTPTests{*test2})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = api.HTTPTestsDelete(tests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if test.HTTPTestID != "" {
+		t.Errorf("Web scenario ID is not cleaned: %#v", test)
+	}
+}
@@ -104,6 +104,186 @@ const (
 	Delta DeltaType = 2
 )
 
+// MarshalJSON encodes t as a string.
+func (t ItemType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ItemType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t ValueType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ValueType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t DataType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *DataType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+// MarshalJSON encodes t as a string.
+func (t DeltaType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *DeltaType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var itemTypeNames = enumNames{
+	kind: "item type",
+	names: map[int][]string{
+		int(ZabbixAgent):       {"Zabbix agent"},
+		int(SNMPv1Agent):       {"SNMPv1 agent"},
+		int(ZabbixTrapper):     {"Zabbix trapper"},
+		int(SimpleCheck):       {"Simple check"},
+		int(SNMPv2Agent):       {"SNMPv2 agent"},
+		int(ZabbixInternal):    {"Zabbix internal"},
+		int(SNMPv3Agent):       {"SNMPv3 agent"},
+		int(ZabbixAgentActive): {"Zabbix agent (active)"},
+		int(ZabbixAggregate):   {"Zabbix aggregate"},
+		int(WebItem):           {"Web item"},
+		int(ExternalCheck):     {"External check"},
+		int(DatabaseMonitor):   {"Database monitor"},
+		int(IPMIAgent):         {"IPMI agent"},
+		int(SSHAgent):          {"SSH agent"},
+		int(TELNETAgent):       {"TELNET agent"},
+		int(Calculated):        {"Calculated"},
+		int(JMXAgent):          {"JMX agent"},
+		int(SNMPTrap):          {"SNMP trap"},
+		int(DependentItem):     {"Dependent item"},
+		int(HTTPAgent):         {"HTTP agent"},
+	},
+}
+
+func (t ItemType) String() string {
+	return itemTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ItemType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ItemType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseItemType(string(text))
+	return
+}
+
+// ParseItemType Parses an item type from its display name or number.
+func ParseItemType(s string) (ItemType, error) {
+	v, err := itemTypeNames.parse(s)
+	return ItemType(v), err
+}
+
+var valueTypeNames = enumNames{
+	kind: "value type",
+	names: map[int][]string{
+		int(Float):     {"Numeric (float)", "Float"},
+		int(Character): {"Character"},
+		int(Log):       {"Log"},
+		int(Unsigned):  {"Numeric (unsigned)", "Unsigned"},
+		int(Text):      {"Text"},
+	},
+}
+
+func (t ValueType) String() string {
+	return valueTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ValueType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ValueType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseValueType(string(text))
+	return
+}
+
+// ParseValueType Parses a value type from its display name or number.
+func ParseValueType(s string) (ValueType, error) {
+	v, err := valueTypeNames.parse(s)
+	return ValueType(v), err
+}
+
+var dataTypeNames = enumNames{
+	kind: "data type",
+	names: map[int][]string{
+		int(Decimal):     {"Decimal"},
+		int(Octal):       {"Octal"},
+		int(Hexadecimal): {"Hexadecimal"},
+		int(Boolean):     {"Boolean"},
+	},
+}
+
+func (t DataType) String() string {
+	return dataTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t DataType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *DataType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseDataType(string(text))
+	return
+}
+
+// ParseDataType Parses a data type from its display name or number.
+func ParseDataType(s string) (DataType, error) {
+	v, err := dataTypeNames.parse(s)
+	return DataType(v), err
+}
+
+var deltaTypeNames = enumNames{
+	kind: "delta type",
+	names: map[int][]string{
+		int(AsIs):  {"As is"},
+		int(Speed): {"Delta (speed per second)", "Speed"},
+		int(Delta): {"Delta (simple change)", "Delta"},
+	},
+}
+
+func (t DeltaType) String() string {
+	return deltaTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t DeltaType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *DeltaType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseDeltaType(string(text))
+	return
+}
+
+// ParseDeltaType Parses a delta type from its display name or number.
+func ParseDeltaType(s string) (DeltaType, error) {
+	v, err := deltaTypeNames.parse(s)
+	return DeltaType(v), err
+}
+
 // Item represent Zabbix item object
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/item/object
 type Item struct {
@@ -113,17 +293,18 @@ type Item struct {
 	InterfaceID  string    `json:"interfaceid,omitempty"`
 	Key          string    `json:"key_"`
 	Name         string    `json:"name"`
-	Type         ItemType  `json:"type,string"`
-	ValueType    ValueType `json:"value_type,string"`
-	DataType     DataType  `json:"data_type,string,omitempty"`
-	Delta        DeltaType `json:"delta,string,omitempty"`
+	Type         ItemType  `json:"type"`
+	ValueType    ValueType `json:"value_type"`
+	DataType     DataType  `json:"data_type,omitempty"` // NOTE: dropped on Zabbix 3.4 onward
+	Delta        DeltaType `json:"delta,omitempty"`     // NOTE: dropped on Zabbix 3.4 onward
 	Description  string    `json:"description"`
 	Error        string    `json:"error,omitempty"`
 	History      string    `json:"history,omitempty"`
 	Trends       string    `json:"trends,omitempty"`
 	TrapperHosts string    `json:"trapper_hosts,omitempty"`
+	ValueMapID   string    `json:"valuemapid,omitempty"`
 
-	// Fields below used only when creating applications
+	// Fields below used only when creating applications, dropped on Zabbix 5.4 onward
 	ApplicationIds []string `json:"applications,omitempty"`
 
 	ItemParent Hosts `json:"hosts,omitempty"`
@@ -132,6 +313,13 @@ type Item struct {
 // Items is an array of Item
 type Items []Item
 
+// itemFields adapts Item fields to the server version
+var itemFields = fieldRules{
+	{Field: "data_type", Feature: FeatureLegacyItemFields},
+	{Field: "delta", Feature: FeatureLegacyItemFields},
+	{Field: "applications", Feature: FeatureApplications},
+}
+
 // ByKey Converts slice to map by key. Panics if there are duplicate keys.
 func (items Items) ByKey() (res map[string]Item) {
 	res = make(map[string]Item, len(items))
@@ -179,15 +367,17 @@ func (api *API) ItemsGetByApplicationID(id string) (res Items, err error) {
 // ItemsCreate Wrapper for item.create
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/item/create
 func (api *API) ItemsCreate(items Items) (err error) {
-	response, err := api.CallWithError("item.create", items)
+	params, err := api.marshalFor(items, itemFields)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("item.create", params)
 	if err != nil {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	itemids := result["itemids"].([]interface{})
-	for i, id := range itemids {
-		items[i].ItemID = id.(string)
+	for i, id := range resultIDs(response.Result, "itemids") {
+		items[i].ItemID = id
 	}
 	return
 }
@@ -195,7 +385,11 @@ func (api *API) ItemsCreate(items Items) (err error) {
 // ItemsUpdate Wrapper for item.update
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/item/update
 func (api *API) ItemsUpdate(items Items) (err error) {
-	_, err = api.CallWithError("item.update", items)
+	params, err := api.marshalFor(items, itemFields)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("item.update", params)
 	return
 }
 
@@ -3,19 +3,19 @@ package zabbix
 // ItemPrototype represent Zabbix item prototype object
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/itemprototype/object
 type ItemPrototype struct {
-	ItemID               string    `json:"itemid,omitempty"`  // Readonly
-	Delay                string    `json:"delay"`             // Required
-	HostID               string    `json:"hostid,omitempty"`  // Required
-	InterfaceID          string    `json:"interfaceid"`       // Required
-	Key                  string    `json:"key_"`              // Required
-	Name                 string    `json:"name"`              // Required
-	Type                 ItemType  `json:"type,string"`       // Required
-	ValueType            ValueType `json:"value_type,string"` // Required
-	RuleID               string    `json:"ruleid,omitempty"`  // Required for item prototype creation
-	AuthType             int       `json:"authtype,omitempty,string"`
-	DataType             DataType  `json:"data_type,omitempty,string"`
+	ItemID               string    `json:"itemid,omitempty"` // Readonly
+	Delay                string    `json:"delay"`            // Required
+	HostID               string    `json:"hostid,omitempty"` // Required
+	InterfaceID          string    `json:"interfaceid"`      // Required
+	Key                  string    `json:"key_"`             // Required
+	Name                 string    `json:"name"`             // Required
+	Type                 ItemType  `json:"type"`             // Required
+	ValueType            ValueType `json:"value_type"`       // Required
+	RuleID               string    `json:"ruleid,omitempty"` // Required for item prototype creation
+	AuthType             Int       `json:"authtype,omitempty"`
+	DataType             DataType  `json:"data_type,omitempty"`
 	DelayFlex            string    `json:"delay_flex,omitempty"`
-	Delta                DeltaType `json:"delta,omitempty,string"`
+	Delta                DeltaType `json:"delta,omitempty"`
 	Description          string    `json:"description,omitempty"`
 	History              string    `json:"history,omitempty"`
 	IpmiSensor           string    `json:"ipmi_sensor,omitempty"`
@@ -29,13 +29,13 @@ type ItemPrototype struct {
 	SnmpCommunity        string    `json:"snmp_community,omitempty"`
 	SnmpOid              string    `json:"snmp_oid,omitempty"`
 	Snmpv3Authpassphrase string    `json:"snmpv3_authpassphrase,omitempty"`
-	Snmpv3Authprotocol   int       `json:"snmpv3_authprotocol,omitempty,string"`
+	Snmpv3Authprotocol   Int       `json:"snmpv3_authprotocol,omitempty"`
 	Snmpv3Contextname    string    `json:"snmpv3_contextname,omitempty"`
 	Snmpv3Privpassphrase string    `json:"snmpv3_privpassphrase,omitempty"`
-	Snmpv3Privprotocol   int       `json:"snmpv3_privprotocol,omitempty,string"`
-	Snmpv3Securitylevel  int       `json:"snmpv3_securitylevel,omitempty,string"`
+	Snmpv3Privprotocol   Int       `json:"snmpv3_privprotocol,omitempty"`
+	Snmpv3Securitylevel  Int       `json:"snmpv3_securitylevel,omitempty"`
 	Snmpv3Securityname   string    `json:"snmpv3_securityname,omitempty"`
-	Status               int       `json:"status,string"`
+	Status               Int       `json:"status"`
 	Templateid           string    `json:"templateid,omitempty"`
 	TrapperHosts         string    `json:"trapper_hosts,omitempty"`
 	Trends               string    `json:"trends,omitempty"`
@@ -50,6 +50,14 @@ type ItemPrototype struct {
 // ItemPrototypes is an array of ItemPrototype
 type ItemPrototypes []ItemPrototype
 
+// itemPrototypeFields adapts ItemPrototype fields to the server version
+var itemPrototypeFields = fieldRules{
+	{Field: "data_type", Feature: FeatureLegacyItemFields},
+	{Field: "delay_flex", Feature: FeatureLegacyItemFields},
+	{Field: "delta", Feature: FeatureLegacyItemFields},
+	{Field: "multiplier", Feature: FeatureLegacyItemFields},
+}
+
 // ItemPrototypesGet Wrapper for item.get
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/itemprototype/get
 func (api *API) ItemPrototypesGet(params Params) (res ItemPrototypes, err error) {
@@ -79,15 +87,17 @@ func (api *API) ItemPrototypeGetByID(id string) (res *ItemPrototype, err error)
 // ItemPrototypesCreate Wrapper for item.create
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/itemprototype/create
 func (api *API) ItemPrototypesCreate(items ItemPrototypes) (err error) {
-	response, err := api.CallWithError("itemprototype.create", items)
+	params, err := api.marshalFor(items, itemPrototypeFields)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("itemprototype.create", params)
 	if err != nil {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	itemids := result["itemids"].([]interface{})
-	for i, id := range itemids {
-		items[i].ItemID = id.(string)
+	for i, id := range resultIDs(response.Result, "itemids") {
+		items[i].ItemID = id
 	}
 	return
 }
@@ -95,7 +105,11 @@ func (api *API) ItemPrototypesCreate(items ItemPrototypes) (err error) {
 // ItemPrototypesUpdate Wrapper for item.update
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/itemprototype/update
 func (api *API) ItemPrototypesUpdate(items ItemPrototypes) (err error) {
-	_, err = api.CallWithError("itemprototype.update", items)
+	params, err := api.marshalFor(items, itemPrototypeFields)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("itemprototype.update", params)
 	return
 }
 
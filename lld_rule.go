@@ -6,7 +6,7 @@ type LLDRulesFilterCondition struct {
 	LLDMacro  string `json:"macro"` // Required
 	Value     string `json:"value"` // Required
 	FormulaID string `json:"formulaid,omitempty"`
-	Operator  int    `json:"operator,omitempty,string"`
+	Operator  Int    `json:"operator,omitempty"`
 }
 
 // LLDRulesFilterConditions is an array of LLDRulesFilterCondition
@@ -15,8 +15,8 @@ type LLDRulesFilterConditions []LLDRulesFilterCondition
 // LLDRuleFilter represent zabbix low-level discovery rules filter(LLD rule filter) object
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/discoveryrule/object#lld_rule_filter
 type LLDRuleFilter struct {
-	Conditions  LLDRulesFilterConditions `json:"conditions"`      // Required
-	EvalType    int                      `json:"evaltype,string"` // Required
+	Conditions  LLDRulesFilterConditions `json:"conditions"` // Required
+	EvalType    Int                      `json:"evaltype"`   // Required
 	EvalFormula string                   `json:"eval_formula,omitempty"`
 	Formula     string                   `json:"formula,omitempty"`
 }
@@ -30,7 +30,7 @@ type LLDRule struct {
 	InterfaceID          string        `json:"interfaceid"`      // Required
 	Key                  string        `json:"key_"`             // Required
 	Name                 string        `json:"name"`             // Required
-	Type                 ItemType      `json:"type,string"`      // Required
+	Type                 ItemType      `json:"type"`             // Required
 	AuthType             string        `json:"authtype,omitempty"`
 	DelayFlex            string        `json:"delay_flex,omitempty"`
 	Description          string        `json:"description,omitempty"`
@@ -45,14 +45,14 @@ type LLDRule struct {
 	SnmpCommunity        string        `json:"snmp_community,omitempty"`
 	SnmpOid              string        `json:"snmp_oid,omitempty"`
 	Snmpv3Authpassphrase string        `json:"snmpv3_authpassphrase,omitempty"`
-	Snmpv3Authprotocol   int           `json:"snmpv3_authprotocol,omitempty,string"`
+	Snmpv3Authprotocol   Int           `json:"snmpv3_authprotocol,omitempty"`
 	Snmpv3Contextname    string        `json:"snmpv3_contextname,omitempty"`
 	Snmpv3Privpassphrase string        `json:"snmpv3_privpassphrase,omitempty"`
-	Snmpv3Privprotocol   int           `json:"snmpv3_privprotocol,omitempty,string"`
-	Snmpv3Securitylevel  int           `json:"snmpv3_securitylevel,omitempty,string"`
+	Snmpv3Privprotocol   Int           `json:"snmpv3_privprotocol,omitempty"`
+	Snmpv3Securitylevel  Int           `json:"snmpv3_securitylevel,omitempty"`
 	Snmpv3Securityname   string        `json:"snmpv3_securityname,omitempty"`
-	State                int           `json:"state,omitempty,string"`
-	Status               int           `json:"status,omitempty,string"`
+	State                Int           `json:"state,omitempty"`
+	Status               Int           `json:"status,omitempty"`
 	Templateid           string        `json:"templateid,omitempty"`
 	TrapperHosts         string        `json:"trapper_hosts,omitempty"`
 	Username             string        `json:"username,omitempty"`
@@ -62,6 +62,11 @@ type LLDRule struct {
 // LLDRules is an array of LLDRule
 type LLDRules []LLDRule
 
+// lldRuleFields adapts LLDRule fields to the server version
+var lldRuleFields = fieldRules{
+	{Field: "delay_flex", Feature: FeatureLegacyItemFields},
+}
+
 // DiscoveryRulesGet Wrapper for discoveryrule.get
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/discoveryrule/get
 func (api *API) DiscoveryRulesGet(params Params) (res LLDRules, err error) {
@@ -91,15 +96,17 @@ func (api *API) DiscoveryRulesGetByID(id string) (res *LLDRule, err error) {
 // DiscoveryRulesCreate Wrapper for discoveryrule.create
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/discoveryrule/create
 func (api *API) DiscoveryRulesCreate(rules LLDRules) error {
-	result, err := api.CallWithError("discoveryrule.create", rules)
+	params, err := api.marshalFor(rules, lldRuleFields)
+	if err != nil {
+		return err
+	}
+	result, err := api.CallWithError("discoveryrule.create", params)
 	if err != nil {
 		return err
 	}
 
-	res := result.Result.(map[string]interface{})
-	ids := res["itemids"].([]interface{})
-	for i, id := range ids {
-		rules[i].ItemID = id.(string)
+	for i, id := range resultIDs(result.Result, "itemids") {
+		rules[i].ItemID = id
 	}
 	return nil
 }
@@ -107,7 +114,11 @@ func (api *API) DiscoveryRulesCreate(rules LLDRules) error {
 // DiscoveryRulesUpdate Wrapper for discoveryrule.update
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/discoveryrule/update
 func (api *API) DiscoveryRulesUpdate(rules LLDRules) error {
-	_, err := api.CallWithError("discoveryrule.update", rules)
+	params, err := api.marshalFor(rules, lldRuleFields)
+	if err != nil {
+		return err
+	}
+	_, err = api.CallWithError("discoveryrule.update", params)
 	return err
 }
 
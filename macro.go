@@ -1,17 +1,183 @@
 package zabbix
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type (
+	// MacroType Type of a user macro
+	// see "type" in https://www.zabbix.com/documentation/5.2/manual/api/reference/usermacro/object
+	MacroType int
+)
+
+const (
+	// MacroText (default) text value
+	MacroText MacroType = 0
+	// MacroSecret secret text value, never returned by the API, new in 5.0
+	MacroSecret MacroType = 1
+	// MacroVault path of a Vault secret, new in 5.2
+	MacroVault MacroType = 2
+)
+
+// macroMask replaces the value of secret macros when printed
+const macroMask = "******"
+
+// MarshalJSON encodes t as a string.
+func (t MacroType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MacroType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var macroTypeNames = enumNames{
+	kind: "macro type",
+	names: map[int][]string{
+		int(MacroText):   {"Text"},
+		int(MacroSecret): {"Secret text", "Secret"},
+		int(MacroVault):  {"Vault secret", "Vault"},
+	},
+}
+
+func (t MacroType) String() string {
+	return macroTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MacroType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MacroType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMacroType(string(text))
+	return
+}
+
+// ParseMacroType Parses a macro type from its display name or number.
+func ParseMacroType(s string) (MacroType, error) {
+	v, err := macroTypeNames.parse(s)
+	return MacroType(v), err
+}
+
 // Macro represent Zabbix User MAcro object
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/usermacro/object
 type Macro struct {
-	MacroID   string `json:"hostmacroids,omitempty"`
+	MacroID   string `json:"hostmacroid,omitempty"`
 	HostID    string `json:"hostid,omitempty"`
 	MacroName string `json:"macro"`
-	Value     string `json:"value"`
+	Value     string `json:"value"` // empty when fetched for secret macros, and then not sent back
+	// NOTE: new in 5.0
+	Type MacroType `json:"type"`
+	// NOTE: new in 4.4
+	Description string `json:"description,omitempty"`
+	// Automatic (readonly) 1 when the macro is managed by a discovery rule
+	Automatic Int `json:"automatic,omitempty"`
 }
 
 // Macros is an array of Macro
 type Macros []Macro
 
+var macroFields = fieldRules{
+	{Field: "type", Feature: FeatureSecretMacros},
+	{Field: "description", Feature: FeatureMacroDescriptions},
+}
+
+// MarshalJSON omits the read-only automatic flag, so that fetched macros can be updated.
+// The empty value of a fetched secret macro is omitted too, so that updating it keeps the secret.
+func (m Macro) MarshalJSON() ([]byte, error) {
+	type macro Macro
+	m.Automatic = 0
+	if m.IsSecret() && m.Value == "" {
+		return json.Marshal(struct {
+			macro
+			Value string `json:"value,omitempty"`
+		}{macro: macro(m)})
+	}
+	return json.Marshal(macro(m))
+}
+
+// IsSecret Tells if the value of the macro is secret, and therefore not returned by the API.
+func (m Macro) IsSecret() bool {
+	return m.Type == MacroSecret
+}
+
+// String returns the macro as "{$NAME}=value", the value being masked for secret macros.
+func (m Macro) String() string {
+	if m.IsSecret() {
+		m.Value = macroMask
+	}
+	return m.MacroName + "=" + m.Value
+}
+
+// GoString prints the macro like %#v does, the value being masked for secret macros.
+func (m Macro) GoString() string {
+	type macro Macro
+	if m.IsSecret() {
+		m.Value = macroMask
+	}
+	return strings.Replace(fmt.Sprintf("%#v", macro(m)), "zabbix.macro{", "zabbix.Macro{", 1)
+}
+
+// requiresMacroType returns an UnsupportedFeature error if the server does not know the macro type,
+// rather than letting it store a secret as plain text.
+func (api *API) requiresMacroType(t MacroType) error {
+	var feature Feature
+	switch t {
+	case MacroSecret:
+		feature = FeatureSecretMacros
+	case MacroVault:
+		feature = FeatureVaultMacros
+	default:
+		return nil
+	}
+	if api.legacy(feature) {
+		return &UnsupportedFeature{feature, api.ServerVersion}
+	}
+	return nil
+}
+
+// macroParams returns the parameters of usermacro.create and usermacro.update for the server version.
+func (api *API) macroParams(macros Macros) (params interface{}, err error) {
+	for _, m := range macros {
+		if err = api.requiresMacroType(m.Type); err != nil {
+			return
+		}
+	}
+	return api.marshalFor(macros, macroFields)
+}
+
+// adaptMacros adapts the macros of marshalled hosts, templates or host prototypes for the server version.
+// Like macroParams, it returns an UnsupportedFeature error for a macro type unknown to the server.
+func (api *API) adaptMacros(params interface{}) error {
+	objects, _ := params.([]interface{})
+	for _, o := range objects {
+		object, _ := o.(map[string]interface{})
+		macros, _ := object["macros"].([]interface{})
+		for _, m := range macros {
+			macro, ok := m.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if t, present := macro["type"]; present {
+				macroType, err := ParseMacroType(fmt.Sprint(t))
+				if err != nil {
+					return err
+				}
+				if err = api.requiresMacroType(macroType); err != nil {
+					return err
+				}
+			}
+			api.adaptFields(macro, macroFields)
+		}
+	}
+	return nil
+}
+
 // MacrosGet Wrapper for usermacro.get
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/usermacro/get
 func (api *API) MacrosGet(params Params) (res Macros, err error) {
@@ -41,15 +207,17 @@ func (api *API) MacroGetByID(id string) (res *Macro, err error) {
 // MacrosCreate Wrapper for usermacro.create
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/usermacro/create
 func (api *API) MacrosCreate(macros Macros) error {
-	response, err := api.CallWithError("usermacro.create", macros)
+	params, err := api.macroParams(macros)
+	if err != nil {
+		return err
+	}
+	response, err := api.CallWithError("usermacro.create", params)
 	if err != nil {
 		return err
 	}
 
-	result := response.Result.(map[string]interface{})
-	macroids := result["hostmacroids"].([]interface{})
-	for i, id := range macroids {
-		macros[i].HostID = id.(string)
+	for i, id := range resultIDs(response.Result, "hostmacroids") {
+		macros[i].MacroID = id
 	}
 	return nil
 }
@@ -57,20 +225,25 @@ func (api *API) MacrosCreate(macros Macros) error {
 // MacrosUpdate Wrapper for usermacro.update
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/usermacro/update
 func (api *API) MacrosUpdate(macros Macros) (err error) {
-	_, err = api.CallWithError("usermacro.create", macros)
+	params, err := api.macroParams(macros)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("usermacro.update", params)
 	return
 }
 
 // MacrosDeleteByIDs Wrapper for usermacro.delete
 // Cleans MacroId in all macro elements if call succeed.
-//https://www.zabbix.com/documentation/3.2/manual/api/reference/usermacro/delete
+// https://www.zabbix.com/documentation/3.2/manual/api/reference/usermacro/delete
 func (api *API) MacrosDeleteByIDs(ids []string) (err error) {
 	response, err := api.CallWithError("usermacro.delete", ids)
+	if err != nil {
+		return
+	}
 
-	result := response.Result.(map[string]interface{})
-	hostmacroids := result["hostmacroids"].([]interface{})
-	if len(ids) != len(hostmacroids) {
-		err = &ExpectedMore{len(ids), len(hostmacroids)}
+	if deleted := resultIDs(response.Result, "hostmacroids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
 	}
 	return
 }
@@ -91,3 +264,163 @@ func (api *API) MacrosDelete(macros Macros) (err error) {
 	}
 	return
 }
+
+// GlobalMacro represent Zabbix global macro object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/object#global-macro
+type GlobalMacro struct {
+	MacroID   string `json:"globalmacroid,omitempty"`
+	MacroName string `json:"macro"`
+	Value     string `json:"value"` // empty when fetched for secret macros, and then not sent back
+	// NOTE: new in 5.0
+	Type MacroType `json:"type"`
+	// NOTE: new in 4.4
+	Description string `json:"description,omitempty"`
+}
+
+// GlobalMacros is an array of GlobalMacro
+type GlobalMacros []GlobalMacro
+
+// MarshalJSON omits the empty value of a fetched secret global macro, so that updating it keeps the secret.
+func (m GlobalMacro) MarshalJSON() ([]byte, error) {
+	type globalMacro GlobalMacro
+	if m.IsSecret() && m.Value == "" {
+		return json.Marshal(struct {
+			globalMacro
+			Value string `json:"value,omitempty"`
+		}{globalMacro: globalMacro(m)})
+	}
+	return json.Marshal(globalMacro(m))
+}
+
+// IsSecret Tells if the value of the global macro is secret, and therefore not returned by the API.
+func (m GlobalMacro) IsSecret() bool {
+	return m.Type == MacroSecret
+}
+
+// String returns the global macro as "{$NAME}=value", the value being masked for secret macros.
+func (m GlobalMacro) String() string {
+	return Macro{MacroName: m.MacroName, Value: m.Value, Type: m.Type}.String()
+}
+
+// GoString prints the global macro like %#v does, the value being masked for secret macros.
+func (m GlobalMacro) GoString() string {
+	type globalMacro GlobalMacro
+	if m.IsSecret() {
+		m.Value = macroMask
+	}
+	return strings.Replace(fmt.Sprintf("%#v", globalMacro(m)), "zabbix.globalMacro{", "zabbix.GlobalMacro{", 1)
+}
+
+// globalMacroParams returns the parameters of usermacro.createglobal and usermacro.updateglobal for the server version.
+func (api *API) globalMacroParams(macros GlobalMacros) (params interface{}, err error) {
+	for _, m := range macros {
+		if err = api.requiresMacroType(m.Type); err != nil {
+			return
+		}
+	}
+	return api.marshalFor(macros, macroFields)
+}
+
+// GlobalMacrosGet Wrapper for usermacro.get with the globalmacro parameter
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/get
+func (api *API) GlobalMacrosGet(params Params) (res GlobalMacros, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	params["globalmacro"] = true
+	err = api.CallWithErrorParse("usermacro.get", params, &res)
+	return
+}
+
+// GlobalMacroGetByID Get global macro by macro ID if there is exactly 1 matching global macro
+func (api *API) GlobalMacroGetByID(id string) (res *GlobalMacro, err error) {
+	macros, err := api.GlobalMacrosGet(Params{"globalmacroids": id})
+	if err != nil {
+		return
+	}
+
+	if len(macros) == 1 {
+		res = &macros[0]
+	} else {
+		e := ExpectedOneResult(len(macros))
+		err = &e
+	}
+	return
+}
+
+// GlobalMacroGetByName Get global macro by name, such as "{$SNMP_COMMUNITY}", if there is exactly 1 matching global macro
+func (api *API) GlobalMacroGetByName(name string) (res *GlobalMacro, err error) {
+	macros, err := api.GlobalMacrosGet(Params{"filter": map[string]string{"macro": name}})
+	if err != nil {
+		return
+	}
+
+	if len(macros) == 1 {
+		res = &macros[0]
+	} else {
+		e := ExpectedOneResult(len(macros))
+		err = &e
+	}
+	return
+}
+
+// GlobalMacrosCreate Wrapper for usermacro.createglobal
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/createglobal
+func (api *API) GlobalMacrosCreate(macros GlobalMacros) (err error) {
+	params, err := api.globalMacroParams(macros)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("usermacro.createglobal", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "globalmacroids") {
+		macros[i].MacroID = id
+	}
+	return
+}
+
+// GlobalMacrosUpdate Wrapper for usermacro.updateglobal
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/updateglobal
+func (api *API) GlobalMacrosUpdate(macros GlobalMacros) (err error) {
+	params, err := api.globalMacroParams(macros)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("usermacro.updateglobal", params)
+	return
+}
+
+// GlobalMacrosDelete Wrapper for usermacro.deleteglobal
+// Cleans MacroID in all global macro elements if call succeed.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/deleteglobal
+func (api *API) GlobalMacrosDelete(macros GlobalMacros) (err error) {
+	ids := make([]string, len(macros))
+	for i, macro := range macros {
+		ids[i] = macro.MacroID
+	}
+
+	err = api.GlobalMacrosDeleteByIDs(ids)
+	if err == nil {
+		for i := range macros {
+			macros[i].MacroID = ""
+		}
+	}
+	return
+}
+
+// GlobalMacrosDeleteByIDs Wrapper for usermacro.deleteglobal
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/deleteglobal
+func (api *API) GlobalMacrosDeleteByIDs(ids []string) (err error) {
+	response, err := api.CallWithError("usermacro.deleteglobal", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "globalmacroids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
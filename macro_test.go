@@ -0,0 +1,213 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestMacroMasking(t *testing.T) {
+	macro := zapi.Macro{MacroName: "{$PASSWORD}", Value: "hunter2", Type: zapi.MacroSecret}
+	for _, s := range []string{macro.String(), fmt.Sprint(macro), fmt.Sprintf("%#v", macro), fmt.Sprintf("%v", zapi.Macros{macro})} {
+		if strings.Contains(s, "hunter2") {
+			t.Errorf("Secret value is not masked: %s", s)
+		}
+	}
+	if s := fmt.Sprintf("%#v", macro); !strings.HasPrefix(s, "zabbix.Macro{") {
+		t.Errorf("Unexpected Go syntax: %s", s)
+	}
+
+	global := zapi.GlobalMacro{MacroName: "{$PASSWORD}", Value: "hunter2", Type: zapi.MacroSecret}
+	if s := fmt.Sprintf("%v %#v", global, global); strings.Contains(s, "hunter2") {
+		t.Errorf("Secret value is not masked: %s", s)
+	}
+
+	macro.Type = zapi.MacroText
+	if macro.IsSecret() || macro.String() != "{$PASSWORD}=hunter2" {
+		t.Errorf("Unexpected text macro: %s", macro)
+	}
+}
+
+func TestMacroTypeParams(t *testing.T) {
+	for version, expected := range map[string]interface{}{"4.4.0": nil, "5.0.0": "0"} {
+		var sent []map[string]interface{}
+		api := testFakeAPI(t, version, func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+			if err := json.Unmarshal(params, &sent); err != nil {
+				t.Fatal(err)
+			}
+			return `{"hostmacroids":["1"],"globalmacroids":["1"],"hostids":["1"]}`, nil
+		})
+		if err := api.MacrosUpdate(zapi.Macros{{MacroID: "1", MacroName: "{$A}", Value: "a", Type: zapi.MacroText}}); err != nil {
+			t.Fatal(err)
+		}
+		if sent[0]["type"] != expected {
+			t.Errorf("Zabbix %s: macro type is %v instead of %v", version, sent[0]["type"], expected)
+		}
+		if err := api.GlobalMacrosUpdate(zapi.GlobalMacros{{MacroID: "1", MacroName: "{$A}", Value: "a"}}); err != nil {
+			t.Fatal(err)
+		}
+		if sent[0]["type"] != expected {
+			t.Errorf("Zabbix %s: global macro type is %v instead of %v", version, sent[0]["type"], expected)
+		}
+		if err := api.HostsUpdate(zapi.Hosts{{HostID: "1", Host: "a", UserMacros: zapi.Macros{{MacroName: "{$A}", Value: "a"}}}}); err != nil {
+			t.Fatal(err)
+		}
+		if macro := sent[0]["macros"].([]interface{})[0].(map[string]interface{}); macro["type"] != expected {
+			t.Errorf("Zabbix %s: host macro type is %v instead of %v", version, macro["type"], expected)
+		}
+	}
+}
+
+func TestNestedMacroTypes(t *testing.T) {
+	api := testFakeAPI(t, "4.4.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		t.Errorf("Unexpected call to %s with %s", method, params)
+		return `{"hostids":["1"],"templateids":["1"]}`, nil
+	})
+	macros := zapi.Macros{{MacroName: "{$A}", Value: "a", Type: zapi.MacroSecret}}
+	errs := []error{
+		api.HostsUpdate(zapi.Hosts{{HostID: "1", Host: "a", UserMacros: macros}}),
+		api.TemplatesUpdate(zapi.Templates{{TemplateID: "1", Host: "a", UserMacros: macros}}),
+		api.HostPrototypesUpdate(zapi.HostPrototypes{{HostID: "1", Host: "a", UserMacros: macros}}),
+	}
+	for _, err := range errs {
+		if e, ok := err.(*zapi.UnsupportedFeature); !ok || e.Feature != zapi.FeatureSecretMacros {
+			t.Errorf("Expected unsupported secret macros, got %v", err)
+		}
+	}
+}
+
+func TestSecretMacroParams(t *testing.T) {
+	var sent []map[string]interface{}
+	api := testFakeAPI(t, "6.0.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		if err := json.Unmarshal(params, &sent); err != nil {
+			t.Fatal(err)
+		}
+		return `{"hostmacroids":["1","2"],"globalmacroids":["1","2"],"hostids":["1"]}`, nil
+	})
+	check := func(kind string, macros []interface{}) {
+		if _, present := macros[0].(map[string]interface{})["value"]; present {
+			t.Errorf("The empty value of a fetched secret %s is sent: %v", kind, macros[0])
+		}
+		if value := macros[1].(map[string]interface{})["value"]; value != "b" {
+			t.Errorf("The value of a secret %s is %v instead of b", kind, value)
+		}
+	}
+
+	if err := api.MacrosUpdate(zapi.Macros{{MacroID: "1", MacroName: "{$A}", Type: zapi.MacroSecret}, {MacroID: "2", MacroName: "{$B}", Value: "b", Type: zapi.MacroSecret}}); err != nil {
+		t.Fatal(err)
+	}
+	check("macro", []interface{}{sent[0], sent[1]})
+	if err := api.GlobalMacrosUpdate(zapi.GlobalMacros{{MacroID: "1", MacroName: "{$A}", Type: zapi.MacroSecret}, {MacroID: "2", MacroName: "{$B}", Value: "b", Type: zapi.MacroSecret}}); err != nil {
+		t.Fatal(err)
+	}
+	check("global macro", []interface{}{sent[0], sent[1]})
+	if err := api.HostsUpdate(zapi.Hosts{{HostID: "1", Host: "a", UserMacros: zapi.Macros{{MacroName: "{$A}", Type: zapi.MacroSecret}, {MacroName: "{$B}", Value: "b", Type: zapi.MacroSecret}}}}); err != nil {
+		t.Fatal(err)
+	}
+	check("host macro", sent[0]["macros"].([]interface{}))
+}
+
+func TestMacros(t *testing.T) {
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	macros := zapi.Macros{{HostID: host.HostID, MacroName: "{$ZABBIX_TESTING}", Value: "created"}}
+	err := api.MacrosCreate(macros)
+	if err != nil {
+		t.Fatal(err)
+	}
+	macro := &macros[0]
+	if macro.MacroID == "" || macro.HostID != host.HostID {
+		t.Errorf("Unexpected macro IDs: %#v", macro)
+	}
+
+	macro.HostID = ""
+	macro.Value = "updated"
+	err = api.MacrosUpdate(macros)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	macro2, err := api.MacroGetByID(macro.MacroID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if macro2.Value != "updated" || macro2.HostID != host.HostID {
+		t.Errorf("Macro is not updated: %#v", macro2)
+	}
+
+	if api.Supports(zapi.FeatureSecretMacros) {
+		secrets := zapi.Macros{{HostID: host.HostID, MacroName: "{$ZABBIX_TESTING_SECRET}", Value: "hunter2", Type: zapi.MacroSecret, Description: "password"}}
+		if err = api.MacrosCreate(secrets); err != nil {
+			t.Fatal(err)
+		}
+		secret, err := api.MacroGetByID(secrets[0].MacroID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !secret.IsSecret() || secret.Value != "" || secret.Description != "password" {
+			t.Errorf("Unexpected secret macro: %#v", secret)
+		}
+		macros = append(macros, secrets...)
+	} else if err = api.MacrosCreate(zapi.Macros{{HostID: host.HostID, MacroName: "{$SECRET}", Type: zapi.MacroSecret}}); err == nil {
+		t.Error("Secret macro created as text")
+	}
+
+	err = api.MacrosDelete(macros)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if macros[0].MacroID != "" {
+		t.Errorf("Macro ID is not cleaned: %#v", macros[0])
+	}
+}
+
+func TestGlobalMacros(t *testing.T) {
+	api := testGetAPI(t)
+
+	name := fmt.Sprintf("{$ZABBIX_TESTING_%d}", rand.Int())
+	macros := zapi.GlobalMacros{{MacroName: name, Value: "public"}}
+	err := api.GlobalMacrosCreate(macros)
+	if err != nil {
+		t.Fatal(err)
+	}
+	macro := &macros[0]
+	if macro.MacroID == "" {
+		t.Errorf("Global macro ID is empty: %#v", macro)
+	}
+
+	macro.Value = "private"
+	err = api.GlobalMacrosUpdate(macros)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	macro2, err := api.GlobalMacroGetByName(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if macro2.MacroID != macro.MacroID || macro2.Value != "private" {
+		t.Errorf("Global macros are not equal:\n%#v\n%#v", macro, macro2)
+	}
+	if _, err = api.GlobalMacroGetByID(macro.MacroID); err != nil {
+		t.Error(err)
+	}
+
+	err = api.GlobalMacrosDelete(macros)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if macro.MacroID != "" {
+		t.Errorf("Global macro ID is not cleaned: %#v", macro)
+	}
+}
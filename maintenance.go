@@ -0,0 +1,537 @@
+package zabbix
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// MaintenanceType whether data is collected during the maintenance
+	// see "maintenance_type" in https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/object
+	MaintenanceType int
+
+	// TimePeriodType type of a maintenance time period
+	// see "timeperiod_type" in https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/object#time_period
+	TimePeriodType int
+
+	// TagsEvalType evaluation method of the maintenance problem tags
+	// see "tags_evaltype" in https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/object
+	TagsEvalType int
+
+	// MaintenanceTagOperator condition operator of a maintenance problem tag
+	// see "operator" in https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/object#problem_tag
+	MaintenanceTagOperator int
+
+	// DayOfWeekMask bitmask of week days, Monday being 1 and Sunday 64
+	DayOfWeekMask int
+
+	// MonthMask bitmask of months, January being 1 and December 2048
+	MonthMask int
+)
+
+const (
+	// WithDataCollection (default) data is collected during the maintenance
+	WithDataCollection MaintenanceType = 0
+	// NoDataCollection data is not collected during the maintenance
+	NoDataCollection MaintenanceType = 1
+)
+
+const (
+	// OneTimePeriod (default) period happening once
+	OneTimePeriod TimePeriodType = 0
+	// DailyPeriod period repeated every few days
+	DailyPeriod TimePeriodType = 2
+	// WeeklyPeriod period repeated on week days every few weeks
+	WeeklyPeriod TimePeriodType = 3
+	// MonthlyPeriod period repeated on a day of some months
+	MonthlyPeriod TimePeriodType = 4
+)
+
+const (
+	// TagsAndOr (default) tags with the same name are combined with Or, others with And
+	TagsAndOr TagsEvalType = 0
+	// TagsOr all tags are combined with Or
+	TagsOr TagsEvalType = 2
+)
+
+const (
+	// TagEquals tag value equals
+	TagEquals MaintenanceTagOperator = 0
+	// TagContains (server default) tag value contains
+	TagContains MaintenanceTagOperator = 2
+)
+
+const (
+	// Monday week day
+	Monday DayOfWeekMask = 1 << iota
+	// Tuesday week day
+	Tuesday
+	// Wednesday week day
+	Wednesday
+	// Thursday week day
+	Thursday
+	// Friday week day
+	Friday
+	// Saturday week day
+	Saturday
+	// Sunday week day
+	Sunday
+)
+
+// AllMonths is the mask of all the months of the year
+const AllMonths MonthMask = 1<<12 - 1
+
+// MarshalJSON encodes t as a string.
+func (t MaintenanceType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MaintenanceType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var maintenanceTypeNames = enumNames{
+	kind: "maintenance type",
+	names: map[int][]string{
+		int(WithDataCollection): {"With data collection"},
+		int(NoDataCollection):   {"No data collection"},
+	},
+}
+
+func (t MaintenanceType) String() string {
+	return maintenanceTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MaintenanceType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MaintenanceType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMaintenanceType(string(text))
+	return
+}
+
+// ParseMaintenanceType Parses a maintenance type from its display name or number.
+func ParseMaintenanceType(s string) (MaintenanceType, error) {
+	v, err := maintenanceTypeNames.parse(s)
+	return MaintenanceType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t TimePeriodType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *TimePeriodType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var timePeriodTypeNames = enumNames{
+	kind: "time period type",
+	names: map[int][]string{
+		int(OneTimePeriod): {"One time only", "One time"},
+		int(DailyPeriod):   {"Daily"},
+		int(WeeklyPeriod):  {"Weekly"},
+		int(MonthlyPeriod): {"Monthly"},
+	},
+}
+
+func (t TimePeriodType) String() string {
+	return timePeriodTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t TimePeriodType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *TimePeriodType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseTimePeriodType(string(text))
+	return
+}
+
+// ParseTimePeriodType Parses a time period type from its display name or number.
+func ParseTimePeriodType(s string) (TimePeriodType, error) {
+	v, err := timePeriodTypeNames.parse(s)
+	return TimePeriodType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t TagsEvalType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *TagsEvalType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var tagsEvalTypeNames = enumNames{
+	kind: "tags evaluation type",
+	names: map[int][]string{
+		int(TagsAndOr): {"And/Or"},
+		int(TagsOr):    {"Or"},
+	},
+}
+
+func (t TagsEvalType) String() string {
+	return tagsEvalTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t TagsEvalType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *TagsEvalType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseTagsEvalType(string(text))
+	return
+}
+
+// ParseTagsEvalType Parses a tags evaluation type from its display name or number.
+func ParseTagsEvalType(s string) (TagsEvalType, error) {
+	v, err := tagsEvalTypeNames.parse(s)
+	return TagsEvalType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t MaintenanceTagOperator) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MaintenanceTagOperator) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var maintenanceTagOperatorNames = enumNames{
+	kind: "tag operator",
+	names: map[int][]string{
+		int(TagEquals):   {"Equals"},
+		int(TagContains): {"Contains"},
+	},
+}
+
+func (t MaintenanceTagOperator) String() string {
+	return maintenanceTagOperatorNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MaintenanceTagOperator) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MaintenanceTagOperator) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMaintenanceTagOperator(string(text))
+	return
+}
+
+// ParseMaintenanceTagOperator Parses a tag operator from its display name or number.
+func ParseMaintenanceTagOperator(s string) (MaintenanceTagOperator, error) {
+	v, err := maintenanceTagOperatorNames.parse(s)
+	return MaintenanceTagOperator(v), err
+}
+
+// WeekDays Returns the mask of the given week days.
+func WeekDays(days ...time.Weekday) (m DayOfWeekMask) {
+	for _, d := range days {
+		// Zabbix weeks begin on Monday
+		m |= 1 << ((uint(d) + 6) % 7)
+	}
+	return
+}
+
+// Contains Tells if the week day is in the mask.
+func (m DayOfWeekMask) Contains(d time.Weekday) bool {
+	return m&WeekDays(d) != 0
+}
+
+// MarshalJSON encodes m as a string.
+func (m DayOfWeekMask) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(m))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (m *DayOfWeekMask) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(m))
+}
+
+func (m DayOfWeekMask) String() string {
+	var names []string
+	for _, d := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday} {
+		if m.Contains(d) {
+			names = append(names, d.String())
+		}
+	}
+	if rest := m &^ (Sunday<<1 - 1); rest != 0 || len(names) == 0 {
+		names = append(names, strconv.Itoa(int(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
+// Months Returns the mask of the given months.
+func Months(months ...time.Month) (m MonthMask) {
+	for _, month := range months {
+		m |= 1 << uint(month-1)
+	}
+	return
+}
+
+// Contains Tells if the month is in the mask.
+func (m MonthMask) Contains(month time.Month) bool {
+	return m&Months(month) != 0
+}
+
+// MarshalJSON encodes m as a string.
+func (m MonthMask) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(m))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (m *MonthMask) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(m))
+}
+
+func (m MonthMask) String() string {
+	var names []string
+	for month := time.January; month <= time.December; month++ {
+		if m.Contains(month) {
+			names = append(names, month.String())
+		}
+	}
+	if rest := m &^ AllMonths; rest != 0 || len(names) == 0 {
+		names = append(names, strconv.Itoa(int(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
+// TimePeriod represent Zabbix maintenance time period object
+// Use NewOneTimePeriod, NewDailyPeriod, NewWeeklyPeriod, NewMonthlyPeriod and NewMonthlyWeekDayPeriod to build valid periods.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/object#time_period
+type TimePeriod struct {
+	Type TimePeriodType `json:"timeperiod_type"`
+	// Period is the duration of the maintenance in seconds.
+	Period Int `json:"period"`
+
+	// StartDate is the beginning of one time periods.
+	StartDate *Timestamp `json:"start_date,omitempty"`
+	// StartTime is the beginning of repeated periods, in seconds since midnight.
+	StartTime Int `json:"start_time,omitempty"`
+	// Every is the number of days of daily periods, of weeks of weekly periods,
+	// or the week of the month of monthly periods given by week day, 5 being the last week.
+	Every     Int           `json:"every,omitempty"`
+	DayOfWeek DayOfWeekMask `json:"dayofweek,omitempty"`
+	Day       Int           `json:"day,omitempty"`
+	Month     MonthMask     `json:"month,omitempty"`
+}
+
+// TimePeriods is an array of TimePeriod
+type TimePeriods []TimePeriod
+
+func seconds(d time.Duration) Int {
+	return Int(d / time.Second)
+}
+
+// NewOneTimePeriod Returns a period happening once.
+func NewOneTimePeriod(start time.Time, length time.Duration) TimePeriod {
+	date := NewTimestamp(start)
+	return TimePeriod{Type: OneTimePeriod, Period: seconds(length), StartDate: &date}
+}
+
+// NewDailyPeriod Returns a period beginning at the given time of day, every few days.
+func NewDailyPeriod(every int, at, length time.Duration) TimePeriod {
+	return TimePeriod{Type: DailyPeriod, Period: seconds(length), StartTime: seconds(at), Every: Int(every)}
+}
+
+// NewWeeklyPeriod Returns a period beginning at the given time of the week days, every few weeks.
+func NewWeeklyPeriod(every int, days DayOfWeekMask, at, length time.Duration) TimePeriod {
+	return TimePeriod{Type: WeeklyPeriod, Period: seconds(length), StartTime: seconds(at), Every: Int(every), DayOfWeek: days}
+}
+
+// NewMonthlyPeriod Returns a period beginning at the given time of a day of the months.
+func NewMonthlyPeriod(months MonthMask, day int, at, length time.Duration) TimePeriod {
+	return TimePeriod{Type: MonthlyPeriod, Period: seconds(length), StartTime: seconds(at), Month: months, Day: Int(day)}
+}
+
+// NewMonthlyWeekDayPeriod Returns a period beginning at the given time of the week days of a week of the months,
+// week being 1 to 4, or 5 for the last week.
+func NewMonthlyWeekDayPeriod(months MonthMask, week int, days DayOfWeekMask, at, length time.Duration) TimePeriod {
+	return TimePeriod{Type: MonthlyPeriod, Period: seconds(length), StartTime: seconds(at), Month: months, Every: Int(week), DayOfWeek: days}
+}
+
+// Length Returns the duration of the period.
+func (p TimePeriod) Length() time.Duration {
+	return time.Duration(p.Period) * time.Second
+}
+
+// TimeOfDay Returns the time of day repeated periods begin at.
+func (p TimePeriod) TimeOfDay() time.Duration {
+	return time.Duration(p.StartTime) * time.Second
+}
+
+// MaintenanceTag represent Zabbix maintenance problem tag object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/object#problem_tag
+type MaintenanceTag struct {
+	Tag      string                 `json:"tag"`
+	Operator MaintenanceTagOperator `json:"operator"`
+	Value    string                 `json:"value,omitempty"`
+}
+
+// MaintenanceTags is an array of MaintenanceTag
+type MaintenanceTags []MaintenanceTag
+
+// Maintenance represent Zabbix maintenance object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/object
+type Maintenance struct {
+	MaintenanceID string          `json:"maintenanceid,omitempty"`
+	Name          string          `json:"name"`
+	ActiveSince   Timestamp       `json:"active_since"`
+	ActiveTill    Timestamp       `json:"active_till"`
+	Description   string          `json:"description,omitempty"`
+	Type          MaintenanceType `json:"maintenance_type"`
+	TimePeriods   TimePeriods     `json:"timeperiods,omitempty"`
+
+	// Host groups and hosts under maintenance, returned with selectGroups and selectHosts.
+	// NOTE: sent as groupids and hostids arrays before Zabbix 6.0
+	Groups HostGroupIDs `json:"groups,omitempty"`
+	Hosts  HostIDs      `json:"hosts,omitempty"`
+
+	// Problem tags suppressed by the maintenance, returned with selectTags.
+	// NOTE: new in 4.0, only used without data collection
+	TagsEvalType TagsEvalType    `json:"tags_evaltype,omitempty"`
+	Tags         MaintenanceTags `json:"tags,omitempty"`
+}
+
+// Maintenances is an array of Maintenance
+type Maintenances []Maintenance
+
+var maintenanceFields = fieldRules{
+	{Field: "groups", Feature: FeatureMaintenanceObjects, Legacy: "groupids", Convert: objectIDs("groupid")},
+	{Field: "hosts", Feature: FeatureMaintenanceObjects, Legacy: "hostids", Convert: objectIDs("hostid")},
+	{Field: "tags_evaltype", Feature: FeatureMaintenanceTags},
+	{Field: "tags", Feature: FeatureMaintenanceTags},
+}
+
+// maintenanceResultFields reads the host groups of Zabbix 6.2 onward, selected by selectHostGroups, as groups.
+var maintenanceResultFields = fieldRules{
+	{Field: "groups", Legacy: "hostgroups"},
+}
+
+// UnmarshalJSON decodes the host groups whatever the server version.
+func (m *Maintenance) UnmarshalJSON(data []byte) (err error) {
+	if data, err = maintenanceResultFields.canonical(data); err != nil {
+		return
+	}
+	type maintenance Maintenance
+	return unmarshalFlexible(data, (*maintenance)(m))
+}
+
+// MaintenancesGet Wrapper for maintenance.get
+// selectGroups is sent as selectHostGroups to Zabbix 6.2 onward.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/get
+func (api *API) MaintenancesGet(params Params) (res Maintenances, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	if groups, present := params["selectGroups"]; present && api.Supports(FeatureTemplateGroups) {
+		delete(params, "selectGroups")
+		params["selectHostGroups"] = groups
+	}
+	err = api.CallWithErrorParse("maintenance.get", params, &res)
+	return
+}
+
+// MaintenanceGetByID Gets maintenance by Id only if there is exactly 1 matching maintenance.
+// Its time periods, host groups, hosts and tags are selected.
+func (api *API) MaintenanceGetByID(id string) (res *Maintenance, err error) {
+	params := Params{
+		"maintenanceids":    id,
+		"selectTimeperiods": "extend",
+		"selectGroups":      []string{"groupid"},
+		"selectHosts":       []string{"hostid"},
+	}
+	if api.Supports(FeatureMaintenanceTags) {
+		params["selectTags"] = "extend"
+	}
+	maintenances, err := api.MaintenancesGet(params)
+	if err != nil {
+		return
+	}
+
+	if len(maintenances) == 1 {
+		res = &maintenances[0]
+	} else {
+		e := ExpectedOneResult(len(maintenances))
+		err = &e
+	}
+	return
+}
+
+// MaintenancesCreate Wrapper for maintenance.create
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/create
+func (api *API) MaintenancesCreate(maintenances Maintenances) (err error) {
+	params, err := api.marshalFor(maintenances, maintenanceFields)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("maintenance.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "maintenanceids") {
+		maintenances[i].MaintenanceID = id
+	}
+	return
+}
+
+// MaintenancesUpdate Wrapper for maintenance.update
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/update
+func (api *API) MaintenancesUpdate(maintenances Maintenances) (err error) {
+	params, err := api.marshalFor(maintenances, maintenanceFields)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("maintenance.update", params)
+	return
+}
+
+// MaintenancesDelete Wrapper for maintenance.delete
+// Cleans MaintenanceID in all maintenances elements if call succeed.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/delete
+func (api *API) MaintenancesDelete(maintenances Maintenances) (err error) {
+	ids := make([]string, len(maintenances))
+	for i, maintenance := range maintenances {
+		ids[i] = maintenance.MaintenanceID
+	}
+
+	err = api.MaintenancesDeleteByIds(ids)
+	if err == nil {
+		for i := range maintenances {
+			maintenances[i].MaintenanceID = ""
+		}
+	}
+	return
+}
+
+// MaintenancesDeleteByIds Wrapper for maintenance.delete
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/maintenance/delete
+func (api *API) MaintenancesDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("maintenance.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "maintenanceids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package zabbix_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func testCreateMaintenance(group *zapi.HostGroup, host *zapi.Host, t *testing.T) *zapi.Maintenance {
+	now := time.Now().Truncate(time.Minute)
+	maintenances := zapi.Maintenances{{
+		Name:        fmt.Sprintf("zabbix-testing-%d", rand.Int()),
+		ActiveSince: zapi.NewTimestamp(now),
+		ActiveTill:  zapi.NewTimestamp(now.AddDate(0, 0, 7)),
+		Type:        zapi.WithDataCollection,
+		TimePeriods: zapi.TimePeriods{
+			zapi.NewOneTimePeriod(now, time.Hour),
+			zapi.NewWeeklyPeriod(1, zapi.Saturday|zapi.Sunday, 2*time.Hour, 30*time.Minute),
+			zapi.NewMonthlyPeriod(zapi.AllMonths, 1, 0, time.Hour),
+		},
+		Groups: zapi.HostGroupIDs{{GroupID: group.GroupID}},
+		Hosts:  zapi.HostIDs{{HostID: host.HostID}},
+	}}
+	err := testGetAPI(t).MaintenancesCreate(maintenances)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &maintenances[0]
+}
+
+func testDeleteMaintenance(maintenance *zapi.Maintenance, t *testing.T) {
+	err := testGetAPI(t).MaintenancesDelete(zapi.Maintenances{*maintenance})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMaintenances(t *testing.T) {
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	maintenance := testCreateMaintenance(group, host, t)
+	if maintenance.MaintenanceID == "" {
+		t.Errorf("Maintenance ID is empty: %#v", maintenance)
+	}
+
+	created, err := api.MaintenanceGetByID(maintenance.MaintenanceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created.TimePeriods) != 3 || len(created.Groups) != 1 || len(created.Hosts) != 1 {
+		t.Errorf("Bad maintenance targets or periods: %#v", created)
+	}
+	if !created.ActiveSince.Equal(maintenance.ActiveSince.Time) {
+		t.Errorf("Bad active since %s, expected %s", created.ActiveSince, maintenance.ActiveSince)
+	}
+
+	maintenance.Description = "updated"
+	maintenance.Hosts = nil
+	if err = api.MaintenancesUpdate(zapi.Maintenances{*maintenance}); err != nil {
+		t.Fatal(err)
+	}
+
+	testDeleteMaintenance(maintenance, t)
+	if maintenance.MaintenanceID != "" {
+		t.Errorf("Maintenance ID should be cleaned: %#v", maintenance)
+	}
+}
+
+func TestMaintenanceMasks(t *testing.T) {
+	days := zapi.WeekDays(time.Monday, time.Sunday)
+	if days != zapi.Monday|zapi.Sunday {
+		t.Errorf("Bad week days mask %d", days)
+	}
+	if s := days.String(); s != "Monday|Sunday" {
+		t.Errorf("Bad week days %q", s)
+	}
+	months := zapi.Months(time.January, time.December)
+	if months != 2049 || !months.Contains(time.December) || months.Contains(time.June) {
+		t.Errorf("Bad months mask %d", months)
+	}
+
+	p := zapi.NewDailyPeriod(2, 90*time.Minute, time.Hour)
+	if p.TimeOfDay() != 90*time.Minute || p.Length() != time.Hour || p.Every != 2 {
+		t.Errorf("Bad daily period %#v", p)
+	}
+}
@@ -0,0 +1,727 @@
+package zabbix
+
+import (
+	"fmt"
+	"math"
+)
+
+type (
+	// MapElementType Type of the object a map element stands for
+	// see "elementtype" in https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object#map-element
+	MapElementType int
+
+	// MapLinkDrawType Line style of a map link
+	// see "drawtype" in https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object#map-link
+	MapLinkDrawType int
+
+	// MapLabelLocation Location of the label of a map element
+	// see "label_location" in https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object#map-element
+	MapLabelLocation int
+
+	// MapShapeType Type of a map shape, new in 3.4
+	// see "type" in https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object#map-shapes
+	MapShapeType int
+)
+
+const (
+	// ElementHost host
+	ElementHost MapElementType = 0
+	// ElementMap map
+	ElementMap MapElementType = 1
+	// ElementTrigger trigger
+	ElementTrigger MapElementType = 2
+	// ElementHostGroup host group
+	ElementHostGroup MapElementType = 3
+	// ElementImage image, standing for no object
+	ElementImage MapElementType = 4
+)
+
+const (
+	// LinkLine (default) line
+	LinkLine MapLinkDrawType = 0
+	// LinkBold bold line
+	LinkBold MapLinkDrawType = 2
+	// LinkDotted dotted line
+	LinkDotted MapLinkDrawType = 3
+	// LinkDashed dashed line
+	LinkDashed MapLinkDrawType = 4
+)
+
+const (
+	// LabelDefault (default) location of the labels of the map
+	LabelDefault MapLabelLocation = -1
+	// LabelBottom below the element
+	LabelBottom MapLabelLocation = 0
+	// LabelLeft left of the element
+	LabelLeft MapLabelLocation = 1
+	// LabelRight right of the element
+	LabelRight MapLabelLocation = 2
+	// LabelTop above the element
+	LabelTop MapLabelLocation = 3
+)
+
+const (
+	// ShapeRectangle (default) rectangle
+	ShapeRectangle MapShapeType = 0
+	// ShapeEllipse ellipse
+	ShapeEllipse MapShapeType = 1
+)
+
+// MarshalJSON encodes t as a string.
+func (t MapElementType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MapElementType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var mapElementTypeNames = enumNames{
+	kind: "map element type",
+	names: map[int][]string{
+		int(ElementHost):      {"Host"},
+		int(ElementMap):       {"Map"},
+		int(ElementTrigger):   {"Trigger"},
+		int(ElementHostGroup): {"Host group"},
+		int(ElementImage):     {"Image"},
+	},
+}
+
+func (t MapElementType) String() string {
+	return mapElementTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MapElementType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MapElementType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMapElementType(string(text))
+	return
+}
+
+// ParseMapElementType Parses a map element type from its display name or number.
+func ParseMapElementType(s string) (MapElementType, error) {
+	v, err := mapElementTypeNames.parse(s)
+	return MapElementType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t MapLinkDrawType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MapLinkDrawType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var mapLinkDrawTypeNames = enumNames{
+	kind: "link style",
+	names: map[int][]string{
+		int(LinkLine):   {"Line"},
+		int(LinkBold):   {"Bold line"},
+		int(LinkDotted): {"Dot", "Dotted line"},
+		int(LinkDashed): {"Dashed line"},
+	},
+}
+
+func (t MapLinkDrawType) String() string {
+	return mapLinkDrawTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MapLinkDrawType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MapLinkDrawType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMapLinkDrawType(string(text))
+	return
+}
+
+// ParseMapLinkDrawType Parses a link style from its display name or number.
+func ParseMapLinkDrawType(s string) (MapLinkDrawType, error) {
+	v, err := mapLinkDrawTypeNames.parse(s)
+	return MapLinkDrawType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t MapLabelLocation) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MapLabelLocation) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var mapLabelLocationNames = enumNames{
+	kind: "label location",
+	names: map[int][]string{
+		int(LabelDefault): {"Default"},
+		int(LabelBottom):  {"Bottom"},
+		int(LabelLeft):    {"Left"},
+		int(LabelRight):   {"Right"},
+		int(LabelTop):     {"Top"},
+	},
+}
+
+func (t MapLabelLocation) String() string {
+	return mapLabelLocationNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MapLabelLocation) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MapLabelLocation) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMapLabelLocation(string(text))
+	return
+}
+
+// ParseMapLabelLocation Parses a label location from its display name or number.
+func ParseMapLabelLocation(s string) (MapLabelLocation, error) {
+	v, err := mapLabelLocationNames.parse(s)
+	return MapLabelLocation(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t MapShapeType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MapShapeType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var mapShapeTypeNames = enumNames{
+	kind: "shape type",
+	names: map[int][]string{
+		int(ShapeRectangle): {"Rectangle"},
+		int(ShapeEllipse):   {"Ellipse"},
+	},
+}
+
+func (t MapShapeType) String() string {
+	return mapShapeTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MapShapeType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MapShapeType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMapShapeType(string(text))
+	return
+}
+
+// ParseMapShapeType Parses a shape type from its display name or number.
+func ParseMapShapeType(s string) (MapShapeType, error) {
+	v, err := mapShapeTypeNames.parse(s)
+	return MapShapeType(v), err
+}
+
+// MapElementObject is an object a map element stands for, only the ID matching the element type being set
+type MapElementObject struct {
+	HostID    string `json:"hostid,omitempty"`
+	GroupID   string `json:"groupid,omitempty"`
+	TriggerID string `json:"triggerid,omitempty"`
+	MapID     string `json:"sysmapid,omitempty"`
+}
+
+// MapElementObjects is an array of MapElementObject
+type MapElementObjects []MapElementObject
+
+// newMapElementObject returns the object of the given ID for a map element type.
+func newMapElementObject(t MapElementType, id string) (o MapElementObject) {
+	switch t {
+	case ElementHost:
+		o.HostID = id
+	case ElementMap:
+		o.MapID = id
+	case ElementTrigger:
+		o.TriggerID = id
+	case ElementHostGroup:
+		o.GroupID = id
+	}
+	return
+}
+
+// MapElementURL represent Zabbix map element URL object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object#map-element-url
+type MapElementURL struct {
+	URLID string `json:"sysmapelementurlid,omitempty"` // Readonly
+	Name  string `json:"name"`
+	URL   string `json:"url"`
+}
+
+// MapElementURLs is an array of MapElementURL
+type MapElementURLs []MapElementURL
+
+// MapElement represent Zabbix map element object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object#map-element
+type MapElement struct {
+	// ElementID identifies the element in the map, it may be set on creation to be referenced by links.
+	ElementID   string         `json:"selementid,omitempty"`
+	ElementType MapElementType `json:"elementtype"` // Required
+	// Objects the element stands for, several triggers or a single other object, none for images.
+	// NOTE: elementid before 3.4
+	Objects MapElementObjects `json:"elements,omitempty"`
+
+	IconOff         string `json:"iconid_off"` // Required, image shown in normal state
+	IconOn          string `json:"iconid_on,omitempty"`
+	IconDisabled    string `json:"iconid_disabled,omitempty"`
+	IconMaintenance string `json:"iconid_maintenance,omitempty"`
+	UseIconMap      Int    `json:"use_iconmap,omitempty"`
+
+	Label         string           `json:"label,omitempty"`
+	LabelLocation MapLabelLocation `json:"label_location"`
+	X             Int              `json:"x"`
+	Y             Int              `json:"y"`
+	// ElementSubtype 1 to show the hosts of a host group element instead of the group.
+	ElementSubtype Int            `json:"elementsubtype,omitempty"`
+	URLs           MapElementURLs `json:"urls,omitempty"`
+}
+
+// MapElements is an array of MapElement
+type MapElements []MapElement
+
+// UnmarshalJSON decodes the object of map elements before 3.4.
+func (e *MapElement) UnmarshalJSON(data []byte) (err error) {
+	type mapElement MapElement
+	legacy := struct {
+		*mapElement
+		ObjectID string `json:"elementid"`
+	}{mapElement: (*mapElement)(e)}
+	if err = unmarshalFlexible(data, &legacy); err != nil {
+		return
+	}
+	if e.Objects == nil && legacy.ObjectID != "" && legacy.ObjectID != "0" {
+		e.Objects = MapElementObjects{newMapElementObject(e.ElementType, legacy.ObjectID)}
+	}
+	return
+}
+
+// MapLinkTrigger represent Zabbix map link trigger object, the state of a link while the trigger is in problem
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object#map-link-trigger
+type MapLinkTrigger struct {
+	LinkTriggerID string          `json:"linktriggerid,omitempty"` // Readonly
+	TriggerID     string          `json:"triggerid"`               // Required
+	Color         string          `json:"color,omitempty"`         // hexadecimal RGB, "DD0000" by default
+	DrawType      MapLinkDrawType `json:"drawtype"`
+}
+
+// MapLinkTriggers is an array of MapLinkTrigger
+type MapLinkTriggers []MapLinkTrigger
+
+// severityColors are the colours of the severities in the default frontend settings
+var severityColors = map[SeverityType]string{
+	NotClassified: "97AAB3",
+	Information:   "7499FF",
+	Warning:       "FFC859",
+	Average:       "FFA059",
+	High:          "E97659",
+	Critical:      "E45959",
+}
+
+// NewMapLinkTrigger Builds a link trigger drawing the link as a bold line of the colour of the severity of the trigger.
+func NewMapLinkTrigger(triggerID string, severity SeverityType) MapLinkTrigger {
+	color, ok := severityColors[severity]
+	if !ok {
+		color = severityColors[NotClassified]
+	}
+	return MapLinkTrigger{TriggerID: triggerID, Color: color, DrawType: LinkBold}
+}
+
+// MapLink represent Zabbix map link object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object#map-link
+type MapLink struct {
+	LinkID     string          `json:"linkid,omitempty"` // Readonly
+	ElementID1 string          `json:"selementid1"`      // Required
+	ElementID2 string          `json:"selementid2"`      // Required
+	Color      string          `json:"color,omitempty"`  // hexadecimal RGB, "000000" by default
+	DrawType   MapLinkDrawType `json:"drawtype"`
+	Label      string          `json:"label,omitempty"`
+	Triggers   MapLinkTriggers `json:"linktriggers,omitempty"`
+}
+
+// MapLinks is an array of MapLink
+type MapLinks []MapLink
+
+// MapShape represent Zabbix map shape object, new in 3.4
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object#map-shapes
+type MapShape struct {
+	ShapeID         string       `json:"sysmap_shapeid,omitempty"` // Readonly
+	Type            MapShapeType `json:"type"`
+	X               Int          `json:"x"`
+	Y               Int          `json:"y"`
+	Width           Int          `json:"width,omitempty"`
+	Height          Int          `json:"height,omitempty"`
+	Text            string       `json:"text,omitempty"`
+	FontSize        Int          `json:"font_size,omitempty"`
+	FontColor       string       `json:"font_color,omitempty"`
+	BackgroundColor string       `json:"background_color,omitempty"`
+	BorderType      Int          `json:"border_type,omitempty"` // 0 for none, 1 solid, 2 dotted, 3 dashed
+	BorderWidth     Int          `json:"border_width,omitempty"`
+	BorderColor     string       `json:"border_color,omitempty"`
+	ZIndex          Int          `json:"zindex,omitempty"`
+}
+
+// MapShapes is an array of MapShape
+type MapShapes []MapShape
+
+// MapURL represent Zabbix map URL object, shown for the elements of a type
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object#map-url
+type MapURL struct {
+	URLID       string         `json:"sysmapurlid,omitempty"` // Readonly
+	Name        string         `json:"name"`
+	URL         string         `json:"url"`
+	ElementType MapElementType `json:"elementtype"`
+}
+
+// MapURLs is an array of MapURL
+type MapURLs []MapURL
+
+// Map represent Zabbix map object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/object
+type Map struct {
+	MapID         string           `json:"sysmapid,omitempty"` // Readonly
+	Name          string           `json:"name"`               // Required
+	Width         Int              `json:"width"`              // Required, pixels
+	Height        Int              `json:"height"`             // Required, pixels
+	BackgroundID  string           `json:"backgroundid,omitempty"`
+	IconMapID     string           `json:"iconmapid,omitempty"`
+	LabelLocation MapLabelLocation `json:"label_location,omitempty"`
+	ExpandMacros  Int              `json:"expand_macros,omitempty"`
+	MarkElements  Int              `json:"markelements,omitempty"`
+	SeverityMin   SeverityType     `json:"severity_min,omitempty"`
+	UserID        string           `json:"userid,omitempty"` // owner, the current user by default
+	Private       Int              `json:"private"`          // 0 for a public map, 1 for a private one
+
+	// Fields below are returned with selectSelements, selectLinks, selectShapes and selectUrls.
+	Elements MapElements `json:"selements,omitempty"`
+	Links    MapLinks    `json:"links,omitempty"`
+	// NOTE: new in 3.4
+	Shapes MapShapes `json:"shapes,omitempty"`
+	URLs   MapURLs   `json:"urls,omitempty"`
+}
+
+// Maps is an array of Map
+type Maps []Map
+
+var mapFields = fieldRules{
+	{Field: "shapes", Feature: FeatureMapShapes},
+}
+
+// mapParams returns the parameters of map.create and map.update for the server version.
+// Before 3.4 the object of elements is given by its ID only.
+func (api *API) mapParams(maps Maps) (params interface{}, err error) {
+	if params, err = api.marshalFor(maps, mapFields); err != nil || !api.legacy(FeatureMapShapes) {
+		return
+	}
+	for _, o := range params.([]interface{}) {
+		elements, _ := o.(map[string]interface{})["selements"].([]interface{})
+		for _, e := range elements {
+			element := e.(map[string]interface{})
+			objects, _ := element["elements"].([]interface{})
+			if len(objects) > 1 {
+				return nil, &UnsupportedFeature{FeatureMapShapes, api.ServerVersion}
+			}
+			delete(element, "elements")
+			if len(objects) == 1 {
+				for _, id := range objects[0].(map[string]interface{}) {
+					element["elementid"] = id
+				}
+			}
+		}
+	}
+	return
+}
+
+// MapsGet Wrapper for map.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/get
+func (api *API) MapsGet(params Params) (res Maps, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("map.get", params, &res)
+	return
+}
+
+// MapGetByID Gets map by Id only if there is exactly 1 matching map.
+// Its elements, links, shapes and URLs are selected.
+func (api *API) MapGetByID(id string) (res *Map, err error) {
+	params := Params{
+		"sysmapids":       id,
+		"selectSelements": "extend",
+		"selectLinks":     "extend",
+		"selectUrls":      "extend",
+	}
+	if api.Supports(FeatureMapShapes) {
+		params["selectShapes"] = "extend"
+	}
+	maps, err := api.MapsGet(params)
+	if err != nil {
+		return
+	}
+
+	if len(maps) == 1 {
+		res = &maps[0]
+	} else {
+		e := ExpectedOneResult(len(maps))
+		err = &e
+	}
+	return
+}
+
+// MapsCreate Wrapper for map.create
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/create
+func (api *API) MapsCreate(maps Maps) (err error) {
+	params, err := api.mapParams(maps)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("map.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "sysmapids") {
+		maps[i].MapID = id
+	}
+	return
+}
+
+// MapsUpdate Wrapper for map.update
+// Elements, links, shapes and URLs given replace those of the map.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/update
+func (api *API) MapsUpdate(maps Maps) (err error) {
+	params, err := api.mapParams(maps)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("map.update", params)
+	return
+}
+
+// MapsDelete Wrapper for map.delete
+// Cleans MapID in all maps elements if call succeed.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/delete
+func (api *API) MapsDelete(maps Maps) (err error) {
+	ids := make([]string, len(maps))
+	for i, m := range maps {
+		ids[i] = m.MapID
+	}
+
+	err = api.MapsDeleteByIds(ids)
+	if err == nil {
+		for i := range maps {
+			maps[i].MapID = ""
+		}
+	}
+	return
+}
+
+// MapsDeleteByIds Wrapper for map.delete
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/map/delete
+func (api *API) MapsDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("map.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "sysmapids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// MapLayout How generated maps place their elements
+type MapLayout int
+
+const (
+	// GridLayout (default) elements in rows of the same length
+	GridLayout MapLayout = iota
+	// TreeLayout elements below the elements linked to them, the elements without incoming edge on the first row
+	TreeLayout
+)
+
+// MapEdge is a link between two objects of a generated map, given by their host or host group ID
+type MapEdge struct {
+	From     string
+	To       string
+	Label    string
+	Triggers MapLinkTriggers // see NewMapLinkTrigger
+}
+
+// MapEdges is an array of MapEdge
+type MapEdges []MapEdge
+
+// MapLayoutOptions tell how to generate a map
+type MapLayoutOptions struct {
+	Layout MapLayout
+	IconID string // Required, image of the elements, such as the ID of the "Server_(96)" image
+	// Spacing is the distance in pixels between elements, 100 by default.
+	Spacing int
+	// Columns is the number of elements by row of grid layouts, enough for a square grid by default.
+	Columns int
+}
+
+// NewHostsMap Builds a map of the hosts laid out on a grid or a tree, linked by the edges.
+func NewHostsMap(name string, hosts Hosts, edges MapEdges, options MapLayoutOptions) (Map, error) {
+	elements := make(MapElements, len(hosts))
+	ids := make([]string, len(hosts))
+	for i, h := range hosts {
+		label := h.Name
+		if label == "" {
+			label = h.Host
+		}
+		elements[i] = MapElement{ElementType: ElementHost, Objects: MapElementObjects{{HostID: h.HostID}}, Label: label}
+		ids[i] = h.HostID
+	}
+	return layoutMap(name, elements, ids, edges, options)
+}
+
+// NewHostGroupsMap Builds a map of the host groups laid out on a grid or a tree, linked by the edges.
+func NewHostGroupsMap(name string, groups HostGroups, edges MapEdges, options MapLayoutOptions) (Map, error) {
+	elements := make(MapElements, len(groups))
+	ids := make([]string, len(groups))
+	for i, g := range groups {
+		elements[i] = MapElement{ElementType: ElementHostGroup, Objects: MapElementObjects{{GroupID: g.GroupID}}, Label: g.Name}
+		ids[i] = g.GroupID
+	}
+	return layoutMap(name, elements, ids, edges, options)
+}
+
+// layoutMap places the elements standing for the objects of the given IDs and links them.
+func layoutMap(name string, elements MapElements, ids []string, edges MapEdges, options MapLayoutOptions) (m Map, err error) {
+	spacing := options.Spacing
+	if spacing <= 0 {
+		spacing = 100
+	}
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+		elements[i].ElementID = fmt.Sprint(i + 1)
+		elements[i].IconOff = options.IconID
+		elements[i].LabelLocation = LabelDefault
+	}
+	m = Map{Name: name, Elements: elements}
+
+	for _, e := range edges {
+		from, ok := index[e.From]
+		to, ok2 := index[e.To]
+		if !ok || !ok2 {
+			return m, fmt.Errorf("zabbix: map edge from %s to %s links objects not in the map", e.From, e.To)
+		}
+		m.Links = append(m.Links, MapLink{
+			ElementID1: elements[from].ElementID,
+			ElementID2: elements[to].ElementID,
+			Label:      e.Label,
+			Triggers:   e.Triggers,
+		})
+	}
+
+	var rows [][]int
+	if options.Layout == TreeLayout {
+		rows = treeRows(len(ids), index, edges)
+	} else {
+		rows = gridRows(len(ids), options.Columns)
+	}
+	widest := 1
+	for _, row := range rows {
+		if len(row) > widest {
+			widest = len(row)
+		}
+	}
+	for y, row := range rows {
+		offset := 0
+		if options.Layout == TreeLayout {
+			// center the rows of trees
+			offset = (widest - len(row)) * spacing / 2
+		}
+		for x, i := range row {
+			m.Elements[i].X = Int(spacing/2 + offset + x*spacing)
+			m.Elements[i].Y = Int(spacing/2 + y*spacing)
+		}
+	}
+	m.Width = Int(widest * spacing)
+	m.Height = Int(spacing)
+	if len(rows) > 1 {
+		m.Height = Int(len(rows) * spacing)
+	}
+	return
+}
+
+// gridRows returns the indexes of n elements by rows of the given number of columns.
+func gridRows(n, columns int) (rows [][]int) {
+	if columns <= 0 {
+		columns = int(math.Ceil(math.Sqrt(float64(n))))
+	}
+	for i := 0; i < n; i++ {
+		if i%columns == 0 {
+			rows = append(rows, nil)
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], i)
+	}
+	return
+}
+
+// treeRows returns the indexes of the elements by rows, each element being on the row below the first element linked to it.
+// Elements only reached through a cycle start new trees on the first row.
+func treeRows(n int, index map[string]int, edges MapEdges) (rows [][]int) {
+	children := make([][]int, n)
+	hasParent := make([]bool, n)
+	for _, e := range edges {
+		from, to := index[e.From], index[e.To]
+		if from != to {
+			children[from] = append(children[from], to)
+			hasParent[to] = true
+		}
+	}
+
+	depth := make([]int, n)
+	placed := make([]bool, n)
+	var queue []int
+	place := func(i, d int) {
+		placed[i], depth[i] = true, d
+		if len(rows) <= d {
+			rows = append(rows, nil)
+		}
+		rows[d] = append(rows[d], i)
+		queue = append(queue, i)
+	}
+	for i := 0; i < n; i++ {
+		if !hasParent[i] {
+			place(i, 0)
+		}
+	}
+	for next := 0; ; {
+		for len(queue) > 0 {
+			i := queue[0]
+			queue = queue[1:]
+			for _, c := range children[i] {
+				if !placed[c] {
+					place(c, depth[i]+1)
+				}
+			}
+		}
+		for next < n && placed[next] {
+			next++
+		}
+		if next == n {
+			return
+		}
+		place(next, 0)
+	}
+}
@@ -0,0 +1,124 @@
+package zabbix_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestMapLayouts(t *testing.T) {
+	hosts := make(zapi.Hosts, 5)
+	for i := range hosts {
+		hosts[i].HostID = fmt.Sprint(i + 10)
+		hosts[i].Host = fmt.Sprintf("host-%d", i)
+	}
+
+	grid, err := zapi.NewHostsMap("grid", hosts, nil, zapi.MapLayoutOptions{IconID: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 3 columns for 5 elements
+	if grid.Width != 300 || grid.Height != 200 || grid.Elements[3].X != 50 || grid.Elements[3].Y != 150 || grid.Elements[2].X != 250 {
+		t.Errorf("Unexpected grid map: %#v", grid)
+	}
+	if grid.Elements[0].Label != "host-0" || grid.Elements[0].Objects[0].HostID != "10" || grid.Elements[0].IconOff != "1" {
+		t.Errorf("Unexpected map element: %#v", grid.Elements[0])
+	}
+
+	// 10 -> 11, 10 -> 12, 11 -> 13, and 14 in a cycle with itself
+	edges := zapi.MapEdges{{From: "10", To: "11"}, {From: "10", To: "12"}, {From: "11", To: "13", Triggers: zapi.MapLinkTriggers{zapi.NewMapLinkTrigger("1", zapi.High)}}, {From: "14", To: "14"}}
+	tree, err := zapi.NewHostsMap("tree", hosts, edges, zapi.MapLayoutOptions{Layout: zapi.TreeLayout, IconID: "1", Spacing: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := map[int][]int{}
+	for i, e := range tree.Elements {
+		rows[int(e.Y)] = append(rows[int(e.Y)], i)
+	}
+	if len(rows[25]) != 2 || len(rows[75]) != 2 || len(rows[125]) != 1 || tree.Height != 150 || tree.Width != 100 {
+		t.Errorf("Unexpected tree map rows %v: %#v", rows, tree)
+	}
+	if tree.Elements[3].X != 50 {
+		t.Errorf("Single element of the row is not centered: %#v", tree.Elements[3])
+	}
+	if len(tree.Links) != 4 || tree.Links[2].ElementID1 != tree.Elements[1].ElementID || tree.Links[2].Triggers[0].DrawType != zapi.LinkBold {
+		t.Errorf("Unexpected links: %#v", tree.Links)
+	}
+
+	if _, err = zapi.NewHostsMap("bad", hosts, zapi.MapEdges{{From: "10", To: "1"}}, zapi.MapLayoutOptions{}); err == nil {
+		t.Error("Expected an error linking an object not in the map")
+	}
+}
+
+func TestMaps(t *testing.T) {
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	var images []struct {
+		ImageID string `json:"imageid"`
+	}
+	err := api.CallWithErrorParse("image.get", zapi.Params{"output": []string{"imageid"}, "filter": map[string]interface{}{"imagetype": 1}, "limit": 1}, &images)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) == 0 {
+		t.Skip("No icon to draw the map with")
+	}
+
+	m, err := zapi.NewHostGroupsMap(fmt.Sprintf("zabbix-testing-%d", rand.Int()), zapi.HostGroups{*group}, nil, zapi.MapLayoutOptions{IconID: images[0].ImageID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostElement := m.Elements[0]
+	hostElement.ElementID = "2"
+	hostElement.ElementType = zapi.ElementHost
+	hostElement.Objects = zapi.MapElementObjects{{HostID: host.HostID}}
+	hostElement.X += 100
+	m.Elements = append(m.Elements, hostElement)
+	m.Links = zapi.MapLinks{{ElementID1: "1", ElementID2: "2", Label: "member"}}
+	m.Width += 100
+
+	maps := zapi.Maps{m}
+	err = api.MapsCreate(maps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maps[0].MapID == "" {
+		t.Errorf("Map ID is empty: %#v", maps[0])
+	}
+
+	m2, err := api.MapGetByID(maps[0].MapID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m2.Name != m.Name || len(m2.Elements) != 2 || len(m2.Links) != 1 || m2.Links[0].Label != "member" {
+		t.Fatalf("Maps are not equal:\n%#v\n%#v", m, m2)
+	}
+	for _, e := range m2.Elements {
+		if len(e.Objects) != 1 || (e.Objects[0].HostID != host.HostID && e.Objects[0].GroupID != group.GroupID) {
+			t.Errorf("Unexpected map element: %#v", e)
+		}
+	}
+
+	m2.Name += "-updated"
+	m2.Links = nil
+	err = api.MapsUpdate(zapi.Maps{*m2})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = api.MapsDelete(maps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maps[0].MapID != "" {
+		t.Errorf("Map ID is not cleaned: %#v", maps[0])
+	}
+}
@@ -0,0 +1,692 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// MediaTypeType transport of a media type
+	// see "type" in https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/object
+	MediaTypeType int
+
+	// SMTPSecurity connection security of email media types
+	// see "smtp_security" in https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/object
+	SMTPSecurity int
+
+	// SMTPAuthentication authentication method of email media types
+	// see "smtp_authentication" in https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/object
+	SMTPAuthentication int
+
+	// MessageFormat format of the messages of email media types
+	// see "content_type" in https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/object
+	MessageFormat int
+
+	// MessageTemplateType operations a message template is used by
+	// see "recovery" in https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/object#message_template
+	MessageTemplateType int
+
+	// SeverityMask bitmask of the severities a user media is used for, 1 << severity
+	// see "severity" in https://www.zabbix.com/documentation/5.0/manual/api/reference/user/object#media
+	SeverityMask int
+)
+
+const (
+	// EmailMediaType email
+	EmailMediaType MediaTypeType = 0
+	// ScriptMediaType script
+	ScriptMediaType MediaTypeType = 1
+	// SMSMediaType SMS
+	SMSMediaType MediaTypeType = 2
+	// WebhookMediaType webhook (new in 4.4)
+	WebhookMediaType MediaTypeType = 4
+)
+
+const (
+	// SMTPSecurityNone (default) no security
+	SMTPSecurityNone SMTPSecurity = 0
+	// SMTPSecuritySTARTTLS STARTTLS
+	SMTPSecuritySTARTTLS SMTPSecurity = 1
+	// SMTPSecuritySSL SSL/TLS
+	SMTPSecuritySSL SMTPSecurity = 2
+)
+
+const (
+	// SMTPAuthenticationNone (default) no authentication
+	SMTPAuthenticationNone SMTPAuthentication = 0
+	// SMTPAuthenticationPassword username and password
+	SMTPAuthenticationPassword SMTPAuthentication = 1
+)
+
+const (
+	// PlainTextFormat plain text
+	PlainTextFormat MessageFormat = 0
+	// HTMLFormat HTML (server default from 5.0)
+	HTMLFormat MessageFormat = 1
+)
+
+const (
+	// OperationsMessage message of operations
+	OperationsMessage MessageTemplateType = 0
+	// RecoveryOperationsMessage message of recovery operations
+	RecoveryOperationsMessage MessageTemplateType = 1
+	// UpdateOperationsMessage message of update operations
+	UpdateOperationsMessage MessageTemplateType = 2
+)
+
+// AllSeverities is the mask of all the severities
+const AllSeverities SeverityMask = 1<<(Critical+1) - 1
+
+// MarshalJSON encodes t as a string.
+func (t MediaTypeType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MediaTypeType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var mediaTypeTypeNames = enumNames{
+	kind: "media type type",
+	names: map[int][]string{
+		int(EmailMediaType):   {"Email"},
+		int(ScriptMediaType):  {"Script"},
+		int(SMSMediaType):     {"SMS"},
+		int(WebhookMediaType): {"Webhook"},
+	},
+}
+
+func (t MediaTypeType) String() string {
+	return mediaTypeTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MediaTypeType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MediaTypeType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMediaTypeType(string(text))
+	return
+}
+
+// ParseMediaTypeType Parses a media type type from its display name or number.
+func ParseMediaTypeType(s string) (MediaTypeType, error) {
+	v, err := mediaTypeTypeNames.parse(s)
+	return MediaTypeType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t SMTPSecurity) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *SMTPSecurity) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var smtpSecurityNames = enumNames{
+	kind: "SMTP security",
+	names: map[int][]string{
+		int(SMTPSecurityNone):     {"None"},
+		int(SMTPSecuritySTARTTLS): {"STARTTLS"},
+		int(SMTPSecuritySSL):      {"SSL/TLS"},
+	},
+}
+
+func (t SMTPSecurity) String() string {
+	return smtpSecurityNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t SMTPSecurity) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *SMTPSecurity) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseSMTPSecurity(string(text))
+	return
+}
+
+// ParseSMTPSecurity Parses an SMTP security from its display name or number.
+func ParseSMTPSecurity(s string) (SMTPSecurity, error) {
+	v, err := smtpSecurityNames.parse(s)
+	return SMTPSecurity(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t SMTPAuthentication) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *SMTPAuthentication) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var smtpAuthenticationNames = enumNames{
+	kind: "SMTP authentication",
+	names: map[int][]string{
+		int(SMTPAuthenticationNone):     {"None"},
+		int(SMTPAuthenticationPassword): {"Username and password", "Password"},
+	},
+}
+
+func (t SMTPAuthentication) String() string {
+	return smtpAuthenticationNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t SMTPAuthentication) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *SMTPAuthentication) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseSMTPAuthentication(string(text))
+	return
+}
+
+// ParseSMTPAuthentication Parses an SMTP authentication from its display name or number.
+func ParseSMTPAuthentication(s string) (SMTPAuthentication, error) {
+	v, err := smtpAuthenticationNames.parse(s)
+	return SMTPAuthentication(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t MessageFormat) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MessageFormat) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var messageFormatNames = enumNames{
+	kind: "message format",
+	names: map[int][]string{
+		int(PlainTextFormat): {"Plain text"},
+		int(HTMLFormat):      {"HTML"},
+	},
+}
+
+func (t MessageFormat) String() string {
+	return messageFormatNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MessageFormat) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MessageFormat) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMessageFormat(string(text))
+	return
+}
+
+// ParseMessageFormat Parses a message format from its display name or number.
+func ParseMessageFormat(s string) (MessageFormat, error) {
+	v, err := messageFormatNames.parse(s)
+	return MessageFormat(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t MessageTemplateType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *MessageTemplateType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var messageTemplateTypeNames = enumNames{
+	kind: "message template type",
+	names: map[int][]string{
+		int(OperationsMessage):         {"Problem", "Operations"},
+		int(RecoveryOperationsMessage): {"Problem recovery", "Recovery operations"},
+		int(UpdateOperationsMessage):   {"Problem update", "Update operations"},
+	},
+}
+
+func (t MessageTemplateType) String() string {
+	return messageTemplateTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t MessageTemplateType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *MessageTemplateType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseMessageTemplateType(string(text))
+	return
+}
+
+// ParseMessageTemplateType Parses a message template type from its display name or number.
+func ParseMessageTemplateType(s string) (MessageTemplateType, error) {
+	v, err := messageTemplateTypeNames.parse(s)
+	return MessageTemplateType(v), err
+}
+
+// Severities Returns the mask of the given severities.
+func Severities(severities ...SeverityType) (m SeverityMask) {
+	for _, s := range severities {
+		m |= 1 << uint(s)
+	}
+	return
+}
+
+// Contains Tells if the severity is in the mask.
+func (m SeverityMask) Contains(s SeverityType) bool {
+	return m&Severities(s) != 0
+}
+
+// MarshalJSON encodes m as a string.
+func (m SeverityMask) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(m))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (m *SeverityMask) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(m))
+}
+
+func (m SeverityMask) String() string {
+	var names []string
+	for s := NotClassified; s <= Critical; s++ {
+		if m.Contains(s) {
+			names = append(names, s.String())
+		}
+	}
+	if rest := m &^ AllSeverities; rest != 0 || len(names) == 0 {
+		names = append(names, strconv.Itoa(int(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
+// MediaTypeParameter represent a webhook parameter
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/object#webhook_parameters
+type MediaTypeParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// MediaTypeParameters is an array of MediaTypeParameter
+type MediaTypeParameters []MediaTypeParameter
+
+// MessageTemplate represent Zabbix media type message template object, new in 5.0
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/object#message_template
+type MessageTemplate struct {
+	EventSource EventType           `json:"eventsource"`
+	Recovery    MessageTemplateType `json:"recovery"`
+	Subject     string              `json:"subject,omitempty"`
+	Message     string              `json:"message,omitempty"`
+}
+
+// MessageTemplates is an array of MessageTemplate
+type MessageTemplates []MessageTemplate
+
+// MediaType represent Zabbix media type object
+// Fields are grouped by the media type they apply to, the others being dropped when sent.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/object
+type MediaType struct {
+	MediaTypeID string        `json:"mediatypeid,omitempty"`
+	Type        MediaTypeType `json:"type"`
+	// NOTE: description before Zabbix 4.4
+	Name   string     `json:"name"`
+	Status StatusType `json:"status"`
+	// NOTE: new in 4.4
+	Description     string `json:"description,omitempty"`
+	MaxSessions     Int    `json:"maxsessions,omitempty"`
+	MaxAttempts     Int    `json:"maxattempts,omitempty"`
+	AttemptInterval string `json:"attempt_interval,omitempty"`
+
+	// Email media types
+	SMTPServer         string             `json:"smtp_server,omitempty"`
+	SMTPPort           Int                `json:"smtp_port,omitempty"`
+	SMTPHelo           string             `json:"smtp_helo,omitempty"`
+	SMTPEmail          string             `json:"smtp_email,omitempty"`
+	SMTPSecurity       SMTPSecurity       `json:"smtp_security"`
+	SMTPVerifyPeer     Int                `json:"smtp_verify_peer"`
+	SMTPVerifyHost     Int                `json:"smtp_verify_host"`
+	SMTPAuthentication SMTPAuthentication `json:"smtp_authentication"`
+	Username           string             `json:"username,omitempty"`
+	Password           string             `json:"passwd,omitempty"`
+	ContentType        MessageFormat      `json:"content_type"`
+
+	// Script media types
+	ExecPath string `json:"exec_path,omitempty"`
+	// ScriptParams are the arguments of the script.
+	// NOTE: exec_params text before Zabbix 6.4, parameters objects onward
+	ScriptParams []string `json:"-"`
+
+	// SMS media types
+	GSMModem string `json:"gsm_modem,omitempty"`
+
+	// Webhook media types
+	// NOTE: new in 4.4
+	Parameters    MediaTypeParameters `json:"parameters,omitempty"`
+	Script        string              `json:"script,omitempty"`
+	Timeout       string              `json:"timeout,omitempty"`
+	ProcessTags   Int                 `json:"process_tags"`
+	ShowEventMenu Int                 `json:"show_event_menu"`
+	EventMenuURL  string              `json:"event_menu_url,omitempty"`
+	EventMenuName string              `json:"event_menu_name,omitempty"`
+
+	// Message templates, returned with selectMessageTemplates.
+	// NOTE: new in 5.0
+	MessageTemplates MessageTemplates `json:"message_templates,omitempty"`
+}
+
+// MediaTypes is an array of MediaType
+type MediaTypes []MediaType
+
+var mediaTypeFields = fieldRules{
+	// the description is dropped before the name takes its place
+	{Field: "description", Feature: FeatureMediaTypeNames},
+	{Field: "name", Feature: FeatureMediaTypeNames, Legacy: "description"},
+	{Field: "message_templates", Feature: FeatureMessageTemplates},
+}
+
+// mediaTypeOnlyFields are the fields only sent for a media type
+var mediaTypeOnlyFields = map[string]MediaTypeType{
+	"smtp_server":         EmailMediaType,
+	"smtp_port":           EmailMediaType,
+	"smtp_helo":           EmailMediaType,
+	"smtp_email":          EmailMediaType,
+	"smtp_security":       EmailMediaType,
+	"smtp_verify_peer":    EmailMediaType,
+	"smtp_verify_host":    EmailMediaType,
+	"smtp_authentication": EmailMediaType,
+	"username":            EmailMediaType,
+	"passwd":              EmailMediaType,
+	"content_type":        EmailMediaType,
+	"exec_path":           ScriptMediaType,
+	"gsm_modem":           SMSMediaType,
+	"parameters":          WebhookMediaType,
+	"script":              WebhookMediaType,
+	"timeout":             WebhookMediaType,
+	"process_tags":        WebhookMediaType,
+	"show_event_menu":     WebhookMediaType,
+	"event_menu_url":      WebhookMediaType,
+	"event_menu_name":     WebhookMediaType,
+}
+
+// scriptParameter is a script media type parameter from Zabbix 6.4
+type scriptParameter struct {
+	SortOrder Int    `json:"sortorder"`
+	Value     string `json:"value"`
+}
+
+// UnmarshalJSON decodes the media type whatever the server version.
+func (m *MediaType) UnmarshalJSON(data []byte) (err error) {
+	type mediaType MediaType
+	var legacy struct {
+		*mediaType
+		ExecParams string          `json:"exec_params"`
+		Parameters json.RawMessage `json:"parameters"`
+	}
+	legacy.mediaType = (*mediaType)(m)
+	if err = unmarshalFlexible(data, &legacy); err != nil {
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return
+	}
+	if _, present := fields["name"]; !present {
+		m.Name, m.Description = m.Description, ""
+	}
+
+	if m.Type != ScriptMediaType {
+		if len(legacy.Parameters) > 0 {
+			err = unmarshalFlexible(legacy.Parameters, &m.Parameters)
+		}
+		return
+	}
+	if legacy.ExecParams != "" {
+		m.ScriptParams = strings.Split(strings.TrimSuffix(legacy.ExecParams, "\n"), "\n")
+	}
+	if len(legacy.Parameters) == 0 {
+		return
+	}
+	var params []scriptParameter
+	if err = unmarshalFlexible(legacy.Parameters, &params); err != nil {
+		return
+	}
+	sort.SliceStable(params, func(i, j int) bool {
+		return params[i].SortOrder < params[j].SortOrder
+	})
+	m.ScriptParams = make([]string, len(params))
+	for i, p := range params {
+		m.ScriptParams[i] = p.Value
+	}
+	return
+}
+
+// mediaTypeParams returns the parameters of mediatype.create and mediatype.update for the server version.
+func (api *API) mediaTypeParams(mediaTypes MediaTypes) (params interface{}, err error) {
+	if params, err = api.marshalFor(mediaTypes, mediaTypeFields); err != nil {
+		return
+	}
+	for i, o := range params.([]interface{}) {
+		object := o.(map[string]interface{})
+		m := mediaTypes[i]
+		for field, t := range mediaTypeOnlyFields {
+			if t != m.Type {
+				delete(object, field)
+			}
+		}
+		if m.Type != ScriptMediaType || m.ScriptParams == nil {
+			continue
+		}
+
+		if api.legacy(FeatureScriptParameters) {
+			execParams := ""
+			for _, p := range m.ScriptParams {
+				execParams += p + "\n"
+			}
+			object["exec_params"] = execParams
+			continue
+		}
+		scriptParams := make([]scriptParameter, len(m.ScriptParams))
+		for j, p := range m.ScriptParams {
+			scriptParams[j] = scriptParameter{Int(j), p}
+		}
+		object["parameters"] = scriptParams
+	}
+	return
+}
+
+// MediaTypesGet Wrapper for mediatype.get
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/get
+func (api *API) MediaTypesGet(params Params) (res MediaTypes, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("mediatype.get", params, &res)
+	return
+}
+
+// MediaTypeGetByID Gets media type by Id only if there is exactly 1 matching media type.
+// Its message templates are selected when supported.
+func (api *API) MediaTypeGetByID(id string) (res *MediaType, err error) {
+	params := Params{"mediatypeids": id}
+	if api.Supports(FeatureMessageTemplates) {
+		params["selectMessageTemplates"] = "extend"
+	}
+	mediaTypes, err := api.MediaTypesGet(params)
+	if err != nil {
+		return
+	}
+
+	if len(mediaTypes) == 1 {
+		res = &mediaTypes[0]
+	} else {
+		e := ExpectedOneResult(len(mediaTypes))
+		err = &e
+	}
+	return
+}
+
+// MediaTypesCreate Wrapper for mediatype.create
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/create
+func (api *API) MediaTypesCreate(mediaTypes MediaTypes) (err error) {
+	params, err := api.mediaTypeParams(mediaTypes)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("mediatype.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "mediatypeids") {
+		mediaTypes[i].MediaTypeID = id
+	}
+	return
+}
+
+// MediaTypesUpdate Wrapper for mediatype.update
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/update
+func (api *API) MediaTypesUpdate(mediaTypes MediaTypes) (err error) {
+	params, err := api.mediaTypeParams(mediaTypes)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("mediatype.update", params)
+	return
+}
+
+// MediaTypesDelete Wrapper for mediatype.delete
+// Cleans MediaTypeID in all mediaTypes elements if call succeed.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/delete
+func (api *API) MediaTypesDelete(mediaTypes MediaTypes) (err error) {
+	ids := make([]string, len(mediaTypes))
+	for i, mediaType := range mediaTypes {
+		ids[i] = mediaType.MediaTypeID
+	}
+
+	err = api.MediaTypesDeleteByIds(ids)
+	if err == nil {
+		for i := range mediaTypes {
+			mediaTypes[i].MediaTypeID = ""
+		}
+	}
+	return
+}
+
+// MediaTypesDeleteByIds Wrapper for mediatype.delete
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/mediatype/delete
+func (api *API) MediaTypesDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("mediatype.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "mediatypeids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// SendTo is the list of recipients of a user media, sent as a single string when there is only one.
+// Zabbix expects an array for email media types and a string for others, see UserMedia.Email.
+type SendTo []string
+
+// MarshalJSON encodes a single recipient as a string, others as an array.
+func (s SendTo) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+	if s == nil {
+		s = SendTo{}
+	}
+	return json.Marshal([]string(s))
+}
+
+// UnmarshalJSON accepts arrays and strings.
+func (s *SendTo) UnmarshalJSON(data []byte) error {
+	var recipients []string
+	if err := json.Unmarshal(data, &recipients); err == nil {
+		*s = recipients
+		return nil
+	}
+	recipient, err := scalarString(data)
+	*s = nil
+	if recipient != "" {
+		*s = SendTo{recipient}
+	}
+	return err
+}
+
+// UserMedia represent Zabbix user media object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/user/object#media
+type UserMedia struct {
+	MediaID     string `json:"mediaid,omitempty"`
+	MediaTypeID string `json:"mediatypeid"`
+	SendTo      SendTo `json:"sendto"`
+	// Active is Enabled (default) or Disabled.
+	Active StatusType `json:"active"`
+	// Severity defaults to AllSeverities.
+	Severity SeverityMask `json:"severity,omitempty"`
+	// Period is the time when the media is used, such as "1-5,09:00-18:00", every time by default.
+	Period string `json:"period,omitempty"`
+	// Email sends the recipients as an array even when there is only one, as Zabbix expects for email media types.
+	// It is set when the recipients are fetched as an array.
+	Email bool `json:"-"`
+}
+
+// UserMedias is an array of UserMedia
+type UserMedias []UserMedia
+
+// MarshalJSON encodes the recipients as an array for email medias.
+func (m UserMedia) MarshalJSON() ([]byte, error) {
+	type userMedia UserMedia
+	if !m.Email {
+		return json.Marshal(userMedia(m))
+	}
+	sendTo := []string(m.SendTo)
+	if sendTo == nil {
+		sendTo = []string{}
+	}
+	return json.Marshal(struct {
+		userMedia
+		SendTo []string `json:"sendto"`
+	}{userMedia(m), sendTo})
+}
+
+// UnmarshalJSON sets Email when the recipients are an array.
+func (m *UserMedia) UnmarshalJSON(data []byte) error {
+	type userMedia UserMedia
+	var shape struct {
+		SendTo json.RawMessage `json:"sendto"`
+	}
+	if err := json.Unmarshal(data, &shape); err != nil {
+		return err
+	}
+	if err := unmarshalFlexible(data, (*userMedia)(m)); err != nil {
+		return err
+	}
+	m.Email = strings.HasPrefix(strings.TrimSpace(string(shape.SendTo)), "[")
+	return nil
+}
+
+// Periods Parses the time periods of the media, nil when it is always used.
+func (m UserMedia) Periods() ([]WeekPeriod, error) {
+	if m.Period == "" {
+		return nil, nil
+	}
+	return ParseWeekPeriods(m.Period)
+}
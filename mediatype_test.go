@@ -0,0 +1,169 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func testCreateMediaType(mediaType zapi.MediaType, t *testing.T) *zapi.MediaType {
+	mediaType.Name = fmt.Sprintf("zabbix-testing-%d", rand.Int())
+	mediaTypes := zapi.MediaTypes{mediaType}
+	err := testGetAPI(t).MediaTypesCreate(mediaTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &mediaTypes[0]
+}
+
+func testDeleteMediaType(mediaType *zapi.MediaType, t *testing.T) {
+	err := testGetAPI(t).MediaTypesDelete(zapi.MediaTypes{*mediaType})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMediaTypes(t *testing.T) {
+	api := testGetAPI(t)
+
+	email := testCreateMediaType(zapi.MediaType{
+		Type:               zapi.EmailMediaType,
+		SMTPServer:         "mail.example.com",
+		SMTPHelo:           "example.com",
+		SMTPEmail:          "zabbix@example.com",
+		SMTPAuthentication: zapi.SMTPAuthenticationPassword,
+		Username:           "zabbix",
+		Password:           "secret",
+	}, t)
+	defer testDeleteMediaType(email, t)
+
+	email2, err := api.MediaTypeGetByID(email.MediaTypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if email2.Name != email.Name || email2.SMTPServer != email.SMTPServer || email2.Username != email.Username {
+		t.Errorf("Media types are not equal:\n%#v\n%#v", email, email2)
+	}
+
+	script := testCreateMediaType(zapi.MediaType{
+		Type:         zapi.ScriptMediaType,
+		ExecPath:     "notify.sh",
+		ScriptParams: []string{"{ALERT.SENDTO}", "{ALERT.SUBJECT}"},
+	}, t)
+	defer testDeleteMediaType(script, t)
+
+	script2, err := api.MediaTypeGetByID(script.MediaTypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(script.ScriptParams, script2.ScriptParams) {
+		t.Errorf("Bad script parameters %#v, expected %#v", script2.ScriptParams, script.ScriptParams)
+	}
+
+	script.Status = zapi.Disabled
+	if err = api.MediaTypesUpdate(zapi.MediaTypes{*script}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebhookMediaTypes(t *testing.T) {
+	skipTestIfVersionLessThan(t, "5.0", "introduced support for message templates")
+
+	api := testGetAPI(t)
+
+	webhook := testCreateMediaType(zapi.MediaType{
+		Type:       zapi.WebhookMediaType,
+		Script:     "return 'OK';",
+		Parameters: zapi.MediaTypeParameters{{Name: "to", Value: "{ALERT.SENDTO}"}},
+		MessageTemplates: zapi.MessageTemplates{
+			{EventSource: zapi.TriggerEvent, Recovery: zapi.OperationsMessage, Subject: "Problem: {EVENT.NAME}", Message: "{EVENT.NAME}"},
+		},
+	}, t)
+	defer testDeleteMediaType(webhook, t)
+
+	webhook2, err := api.MediaTypeGetByID(webhook.MediaTypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(webhook.Parameters, webhook2.Parameters) || len(webhook2.MessageTemplates) != 1 {
+		t.Errorf("Media types are not equal:\n%#v\n%#v", webhook, webhook2)
+	}
+}
+
+func TestUserMedias(t *testing.T) {
+	api := testGetAPI(t)
+
+	users, err := api.UsersGet(zapi.Params{"selectMedias": "extend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range users {
+		for _, m := range u.Medias {
+			if _, err = m.Periods(); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+}
+
+func TestUserMediasEncoding(t *testing.T) {
+	b, err := json.Marshal(zapi.UserMedias{
+		{MediaTypeID: "1", SendTo: zapi.SendTo{"admin@example.com"}, Severity: zapi.Severities(zapi.High, zapi.Critical)},
+		{MediaTypeID: "1", SendTo: zapi.SendTo{"a@example.com", "b@example.com"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"mediatypeid":"1","sendto":"admin@example.com","active":"0","severity":"48"},` +
+		`{"mediatypeid":"1","sendto":["a@example.com","b@example.com"],"active":"0"}]`
+	if string(b) != expected {
+		t.Errorf("Bad marshaled medias:\n%s\n%s", b, expected)
+	}
+	if s := zapi.Severities(zapi.High, zapi.Critical).String(); s != "High|Disaster" {
+		t.Errorf("Bad severity mask %q", s)
+	}
+}
+
+func TestUserEmailRecipients(t *testing.T) {
+	var sent []struct {
+		Medias []struct {
+			MediaTypeID string          `json:"mediatypeid"`
+			SendTo      json.RawMessage `json:"sendto"`
+		} `json:"medias"`
+	}
+	api := testFakeAPI(t, "6.0.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		if method == "user.create" {
+			if err := json.Unmarshal(params, &sent); err != nil {
+				t.Error(err)
+			}
+			return `{"userids":["1"]}`, nil
+		}
+		return "", &zapi.Error{Code: -32602, Message: "Invalid params.", Data: method}
+	})
+
+	var medias zapi.UserMedias
+	if err := json.Unmarshal([]byte(`[{"mediatypeid":"1","sendto":["admin@example.com"]},{"mediatypeid":"3","sendto":"+33600000000"}]`), &medias); err != nil {
+		t.Fatal(err)
+	}
+	if len(medias) != 2 || !medias[0].Email || medias[1].Email {
+		t.Fatalf("Bad email flags: %#v", medias)
+	}
+	medias = append(medias, zapi.UserMedia{MediaTypeID: "1", SendTo: zapi.SendTo{"other@example.com"}, Email: true})
+
+	if err := api.UsersCreate(zapi.Users{{Username: "a", Medias: medias}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 || len(sent[0].Medias) != 3 {
+		t.Fatalf("Unexpected users: %#v", sent)
+	}
+	for i, expected := range []string{`["admin@example.com"]`, `"+33600000000"`, `["other@example.com"]`} {
+		if s := string(sent[0].Medias[i].SendTo); s != expected {
+			t.Errorf("Bad recipient %s, expected %s", s, expected)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"net"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ProxyMode Whether the proxy connects to the server or the server to the proxy
+	// see "operating_mode" in https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxy/object
+	ProxyMode int
+
+	// TLSMode Bitmask of the encryption of connections
+	// see "tls_connect" and "tls_accept" in https://www.zabbix.com/documentation/5.0/manual/api/reference/proxy/object
+	TLSMode int
+
+	// ProxyGroupState (readonly) State of a proxy group, new in 7.0
+	// see "state" in https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxygroup/object
+	ProxyGroupState int
+)
+
+const (
+	// ActiveProxy (default) proxy connecting to the server
+	// NOTE: status 5 before Zabbix 7.0
+	ActiveProxy ProxyMode = 0
+	// PassiveProxy proxy the server connects to
+	// NOTE: status 6 before Zabbix 7.0
+	PassiveProxy ProxyMode = 1
+)
+
+const (
+	// TLSNoEncryption (default) no encryption
+	TLSNoEncryption TLSMode = 1
+	// TLSPSK pre-shared key encryption
+	TLSPSK TLSMode = 2
+	// TLSCertificate certificate encryption
+	TLSCertificate TLSMode = 4
+)
+
+const (
+	// ProxyGroupUnknown state unknown
+	ProxyGroupUnknown ProxyGroupState = 0
+	// ProxyGroupOffline less proxies online than the minimum
+	ProxyGroupOffline ProxyGroupState = 1
+	// ProxyGroupRecovering proxies coming back online
+	ProxyGroupRecovering ProxyGroupState = 2
+	// ProxyGroupOnline enough proxies online
+	ProxyGroupOnline ProxyGroupState = 3
+	// ProxyGroupDegrading proxies going offline
+	ProxyGroupDegrading ProxyGroupState = 4
+)
+
+// MarshalJSON encodes t as a string.
+func (t ProxyMode) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ProxyMode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var proxyModeNames = enumNames{
+	kind: "proxy mode",
+	names: map[int][]string{
+		int(ActiveProxy):  {"Active"},
+		int(PassiveProxy): {"Passive"},
+	},
+}
+
+func (t ProxyMode) String() string {
+	return proxyModeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ProxyMode) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ProxyMode) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseProxyMode(string(text))
+	return
+}
+
+// ParseProxyMode Parses a proxy mode from its display name or number.
+func ParseProxyMode(s string) (ProxyMode, error) {
+	v, err := proxyModeNames.parse(s)
+	return ProxyMode(v), err
+}
+
+// MarshalJSON encodes m as a string.
+func (m TLSMode) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(m))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (m *TLSMode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(m))
+}
+
+func (m TLSMode) String() string {
+	var names []string
+	for _, known := range []struct {
+		mode TLSMode
+		name string
+	}{{TLSNoEncryption, "No encryption"}, {TLSPSK, "PSK"}, {TLSCertificate, "Certificate"}} {
+		if m&known.mode != 0 {
+			names = append(names, known.name)
+		}
+	}
+	if rest := m &^ (TLSNoEncryption | TLSPSK | TLSCertificate); rest != 0 || len(names) == 0 {
+		names = append(names, strconv.Itoa(int(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
+// MarshalJSON encodes t as a string.
+func (t ProxyGroupState) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ProxyGroupState) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var proxyGroupStateNames = enumNames{
+	kind: "proxy group state",
+	names: map[int][]string{
+		int(ProxyGroupUnknown):    {"Unknown"},
+		int(ProxyGroupOffline):    {"Offline"},
+		int(ProxyGroupRecovering): {"Recovering"},
+		int(ProxyGroupOnline):     {"Online"},
+		int(ProxyGroupDegrading):  {"Degrading"},
+	},
+}
+
+func (t ProxyGroupState) String() string {
+	return proxyGroupStateNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ProxyGroupState) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ProxyGroupState) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseProxyGroupState(string(text))
+	return
+}
+
+// ParseProxyGroupState Parses a proxy group state from its display name or number.
+func ParseProxyGroupState(s string) (ProxyGroupState, error) {
+	v, err := proxyGroupStateNames.parse(s)
+	return ProxyGroupState(v), err
+}
+
+// Proxy represent Zabbix proxy object, with the field names of Zabbix 7.0
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxy/object
+type Proxy struct {
+	ProxyID string `json:"proxyid,omitempty"`
+	// NOTE: host before Zabbix 7.0
+	Name string `json:"name"`
+	// NOTE: status before Zabbix 7.0
+	OperatingMode ProxyMode `json:"operating_mode"`
+	Description   string    `json:"description,omitempty"`
+
+	// Addresses active proxies may connect from, comma separated.
+	// NOTE: proxy_address before Zabbix 7.0
+	AllowedAddresses string `json:"allowed_addresses,omitempty"`
+	// Address and port of passive proxies.
+	// NOTE: interface object before Zabbix 7.0
+	Address string `json:"address,omitempty"`
+	Port    string `json:"port,omitempty"`
+
+	TLSConnect     TLSMode `json:"tls_connect,omitempty"`
+	TLSAccept      TLSMode `json:"tls_accept,omitempty"`
+	TLSIssuer      string  `json:"tls_issuer,omitempty"`
+	TLSSubject     string  `json:"tls_subject,omitempty"`
+	TLSPSKIdentity string  `json:"tls_psk_identity,omitempty"`
+	TLSPSK         string  `json:"tls_psk,omitempty"`
+
+	// Proxy group of the proxy and its address for the hosts of the group.
+	// NOTE: new in 7.0
+	ProxyGroupID string `json:"proxy_groupid,omitempty"`
+	LocalAddress string `json:"local_address,omitempty"`
+	LocalPort    string `json:"local_port,omitempty"`
+
+	// Hosts monitored by the proxy, returned with selectHosts.
+	Hosts HostIDs `json:"hosts,omitempty"`
+}
+
+// Proxies is an array of Proxy
+type Proxies []Proxy
+
+var proxyFields = fieldRules{
+	{Field: "name", Feature: FeatureProxyFields, Legacy: "host"},
+	{
+		Field: "operating_mode", Feature: FeatureProxyFields, Legacy: "status",
+		Convert: mapValue(map[string]string{"0": "5", "1": "6"}),
+		Restore: mapValue(map[string]string{"5": "0", "6": "1"}),
+	},
+	{Field: "allowed_addresses", Feature: FeatureProxyFields, Legacy: "proxy_address"},
+	{Field: "proxy_groupid", Feature: FeatureProxyGroups},
+	{Field: "local_address", Feature: FeatureProxyGroups},
+	{Field: "local_port", Feature: FeatureProxyGroups},
+}
+
+// proxyInterface is the interface of passive proxies before Zabbix 7.0
+type proxyInterface struct {
+	IP    string `json:"ip"`
+	DNS   string `json:"dns"`
+	UseIP Int    `json:"useip"`
+	Port  string `json:"port"`
+}
+
+// UnmarshalJSON decodes the proxy whatever the server version.
+func (p *Proxy) UnmarshalJSON(data []byte) (err error) {
+	if data, err = proxyFields.canonical(data); err != nil {
+		return
+	}
+	type proxy Proxy
+	var legacy struct {
+		*proxy
+		Interface json.RawMessage `json:"interface"`
+	}
+	legacy.proxy = (*proxy)(p)
+	if err = unmarshalFlexible(data, &legacy); err != nil {
+		return
+	}
+
+	// the interface is an empty array for active proxies
+	var i proxyInterface
+	if len(legacy.Interface) > 0 && legacy.Interface[0] == '{' {
+		if err = unmarshalFlexible(legacy.Interface, &i); err != nil {
+			return
+		}
+		p.Address, p.Port = i.DNS, i.Port
+		if i.UseIP == 1 {
+			p.Address = i.IP
+		}
+	}
+	return
+}
+
+// proxyParams returns the parameters of proxy.create and proxy.update for the server version.
+func (api *API) proxyParams(proxies Proxies) (params interface{}, err error) {
+	if params, err = api.marshalFor(proxies, proxyFields); err != nil || !api.legacy(FeatureProxyFields) {
+		return
+	}
+	for _, o := range params.([]interface{}) {
+		object := o.(map[string]interface{})
+		address, _ := object["address"].(string)
+		port, _ := object["port"].(string)
+		delete(object, "address")
+		delete(object, "port")
+		if address == "" && port == "" {
+			continue
+		}
+		i := proxyInterface{DNS: address, Port: port}
+		if net.ParseIP(address) != nil {
+			i = proxyInterface{IP: address, UseIP: 1, Port: port}
+		}
+		object["interface"] = i
+	}
+	return
+}
+
+// ProxiesGet Wrapper for proxy.get
+// selectInterface is added before Zabbix 7.0 to fill Address and Port.
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxy/get
+func (api *API) ProxiesGet(params Params) (res Proxies, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	if _, present := params["selectInterface"]; !present && !api.Supports(FeatureProxyFields) {
+		params["selectInterface"] = "extend"
+	}
+	err = api.CallWithErrorParse("proxy.get", params, &res)
+	return
+}
+
+// ProxyGetByID Gets proxy by Id only if there is exactly 1 matching proxy.
+func (api *API) ProxyGetByID(id string) (res *Proxy, err error) {
+	proxies, err := api.ProxiesGet(Params{"proxyids": id})
+	if err != nil {
+		return
+	}
+
+	if len(proxies) == 1 {
+		res = &proxies[0]
+	} else {
+		e := ExpectedOneResult(len(proxies))
+		err = &e
+	}
+	return
+}
+
+// ProxiesCreate Wrapper for proxy.create
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxy/create
+func (api *API) ProxiesCreate(proxies Proxies) (err error) {
+	params, err := api.proxyParams(proxies)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("proxy.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "proxyids") {
+		proxies[i].ProxyID = id
+	}
+	return
+}
+
+// ProxiesUpdate Wrapper for proxy.update
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxy/update
+func (api *API) ProxiesUpdate(proxies Proxies) (err error) {
+	params, err := api.proxyParams(proxies)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("proxy.update", params)
+	return
+}
+
+// ProxiesDelete Wrapper for proxy.delete
+// Cleans ProxyID in all proxies elements if call succeed.
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxy/delete
+func (api *API) ProxiesDelete(proxies Proxies) (err error) {
+	ids := make([]string, len(proxies))
+	for i, proxy := range proxies {
+		ids[i] = proxy.ProxyID
+	}
+
+	err = api.ProxiesDeleteByIds(ids)
+	if err == nil {
+		for i := range proxies {
+			proxies[i].ProxyID = ""
+		}
+	}
+	return
+}
+
+// ProxiesDeleteByIds Wrapper for proxy.delete
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxy/delete
+func (api *API) ProxiesDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("proxy.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "proxyids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// ProxyGroup represent Zabbix proxy group object, new in 7.0
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxygroup/object
+type ProxyGroup struct {
+	ProxyGroupID string `json:"proxy_groupid,omitempty"`
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	// FailoverDelay is the time after which an unreachable proxy has its hosts moved to other proxies.
+	FailoverDelay string `json:"failover_delay,omitempty"`
+	// MinOnline is the minimum number of online proxies for the group to be online.
+	MinOnline string          `json:"min_online,omitempty"`
+	State     ProxyGroupState `json:"state,omitempty"`
+}
+
+// ProxyGroups is an array of ProxyGroup
+type ProxyGroups []ProxyGroup
+
+// MarshalJSON omits the read-only state, so that fetched groups can be updated.
+func (g ProxyGroup) MarshalJSON() ([]byte, error) {
+	type proxyGroup ProxyGroup
+	g.State = ProxyGroupUnknown
+	return json.Marshal(proxyGroup(g))
+}
+
+// ProxyGroupsGet Wrapper for proxygroup.get
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxygroup/get
+func (api *API) ProxyGroupsGet(params Params) (res ProxyGroups, err error) {
+	if err = api.requires(FeatureProxyGroups); err != nil {
+		return
+	}
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("proxygroup.get", params, &res)
+	return
+}
+
+// ProxyGroupGetByID Gets proxy group by Id only if there is exactly 1 matching proxy group.
+func (api *API) ProxyGroupGetByID(id string) (res *ProxyGroup, err error) {
+	groups, err := api.ProxyGroupsGet(Params{"proxy_groupids": id})
+	if err != nil {
+		return
+	}
+
+	if len(groups) == 1 {
+		res = &groups[0]
+	} else {
+		e := ExpectedOneResult(len(groups))
+		err = &e
+	}
+	return
+}
+
+// ProxyGroupsCreate Wrapper for proxygroup.create
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxygroup/create
+func (api *API) ProxyGroupsCreate(groups ProxyGroups) (err error) {
+	if err = api.requires(FeatureProxyGroups); err != nil {
+		return
+	}
+	response, err := api.CallWithError("proxygroup.create", groups)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "proxy_groupids") {
+		groups[i].ProxyGroupID = id
+	}
+	return
+}
+
+// ProxyGroupsUpdate Wrapper for proxygroup.update
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxygroup/update
+func (api *API) ProxyGroupsUpdate(groups ProxyGroups) (err error) {
+	if err = api.requires(FeatureProxyGroups); err != nil {
+		return
+	}
+	_, err = api.CallWithError("proxygroup.update", groups)
+	return
+}
+
+// ProxyGroupsDelete Wrapper for proxygroup.delete
+// Cleans ProxyGroupID in all groups elements if call succeed.
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxygroup/delete
+func (api *API) ProxyGroupsDelete(groups ProxyGroups) (err error) {
+	ids := make([]string, len(groups))
+	for i, group := range groups {
+		ids[i] = group.ProxyGroupID
+	}
+
+	err = api.ProxyGroupsDeleteByIds(ids)
+	if err == nil {
+		for i := range groups {
+			groups[i].ProxyGroupID = ""
+		}
+	}
+	return
+}
+
+// ProxyGroupsDeleteByIds Wrapper for proxygroup.delete
+// https://www.zabbix.com/documentation/7.0/en/manual/api/reference/proxygroup/delete
+func (api *API) ProxyGroupsDeleteByIds(ids []string) (err error) {
+	if err = api.requires(FeatureProxyGroups); err != nil {
+		return
+	}
+	response, err := api.CallWithError("proxygroup.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "proxy_groupids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
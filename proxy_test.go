@@ -0,0 +1,181 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func testCreateProxy(mode zapi.ProxyMode, t *testing.T) *zapi.Proxy {
+	proxies := zapi.Proxies{{
+		Name:          fmt.Sprintf("zabbix-testing-%d", rand.Int()),
+		OperatingMode: mode,
+	}}
+	if mode == zapi.PassiveProxy {
+		proxies[0].Address = "127.0.0.1"
+		proxies[0].Port = "10051"
+	}
+	err := testGetAPI(t).ProxiesCreate(proxies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &proxies[0]
+}
+
+func testDeleteProxy(proxy *zapi.Proxy, t *testing.T) {
+	err := testGetAPI(t).ProxiesDelete(zapi.Proxies{*proxy})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProxies(t *testing.T) {
+	api := testGetAPI(t)
+
+	for _, mode := range []zapi.ProxyMode{zapi.ActiveProxy, zapi.PassiveProxy} {
+		proxy := testCreateProxy(mode, t)
+		if proxy.ProxyID == "" {
+			t.Errorf("Proxy ID is empty: %#v", proxy)
+		}
+
+		proxy2, err := api.ProxyGetByID(proxy.ProxyID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if proxy2.Name != proxy.Name || proxy2.OperatingMode != mode || proxy2.Address != proxy.Address || proxy2.Port != proxy.Port {
+			t.Errorf("Proxies are not equal:\n%#v\n%#v", proxy, proxy2)
+		}
+
+		proxy.Description = "updated"
+		if err = api.ProxiesUpdate(zapi.Proxies{*proxy}); err != nil {
+			t.Fatal(err)
+		}
+
+		testDeleteProxy(proxy, t)
+	}
+}
+
+func TestHostsWithProxy(t *testing.T) {
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	proxy := testCreateProxy(zapi.ActiveProxy, t)
+	defer testDeleteProxy(proxy, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	host.ProxyID = proxy.ProxyID
+	host.GroupIds = nil
+	host.Interfaces = nil
+	if err := api.HostsUpdate(zapi.Hosts{*host}); err != nil {
+		t.Fatal(err)
+	}
+
+	host2, err := api.HostGetByID(host.HostID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host2.ProxyID != proxy.ProxyID {
+		t.Errorf("Bad host proxy %q, expected %q", host2.ProxyID, proxy.ProxyID)
+	}
+	if api.Supports(zapi.FeatureProxyGroups) && (host2.MonitoredBy == nil || *host2.MonitoredBy != zapi.MonitoredByProxy) {
+		t.Errorf("Bad host monitoring source %v", host2.MonitoredBy)
+	}
+
+	// a partial update keeps the proxy
+	if err = api.HostsUpdate(zapi.Hosts{{HostID: host.HostID, Name: host.Host + " renamed"}}); err != nil {
+		t.Fatal(err)
+	}
+	if host2, err = api.HostGetByID(host.HostID); err != nil {
+		t.Fatal(err)
+	}
+	if host2.ProxyID != proxy.ProxyID {
+		t.Errorf("Host lost its proxy on a partial update: %#v", host2)
+	}
+
+	// back to the server
+	server := zapi.MonitoredByServer
+	host2.ProxyID = ""
+	host2.MonitoredBy = &server
+	if err = api.HostsUpdate(zapi.Hosts{*host2}); err != nil {
+		t.Fatal(err)
+	}
+	if host2, err = api.HostGetByID(host.HostID); err != nil {
+		t.Fatal(err)
+	}
+	if host2.ProxyID != "" || (host2.MonitoredBy != nil && *host2.MonitoredBy != zapi.MonitoredByServer) {
+		t.Errorf("Host is still monitored by a proxy: %#v", host2)
+	}
+}
+
+func TestHostProxyParams(t *testing.T) {
+	server, proxy := zapi.MonitoredByServer, zapi.MonitoredByProxy
+	cases := []struct {
+		version     string
+		proxyID     string
+		monitoredBy *zapi.MonitoredBy
+		expected    map[string]interface{}
+	}{
+		{"6.0.0", "", nil, map[string]interface{}{}},
+		{"6.0.0", "", &server, map[string]interface{}{"proxy_hostid": "0"}},
+		{"6.0.0", "0", nil, map[string]interface{}{"proxy_hostid": "0"}},
+		{"6.0.0", "12", nil, map[string]interface{}{"proxy_hostid": "12"}},
+		{"7.0.0", "", nil, map[string]interface{}{}},
+		{"7.0.0", "", &server, map[string]interface{}{"monitored_by": "0"}},
+		{"7.0.0", "0", nil, map[string]interface{}{"monitored_by": "0", "proxyid": "0"}},
+		{"7.0.0", "12", nil, map[string]interface{}{"monitored_by": "1", "proxyid": "12"}},
+		{"7.0.0", "12", &proxy, map[string]interface{}{"monitored_by": "1", "proxyid": "12"}},
+	}
+	for _, c := range cases {
+		var sent []map[string]interface{}
+		api := testFakeAPI(t, c.version, func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+			if err := json.Unmarshal(params, &sent); err != nil {
+				t.Fatal(err)
+			}
+			return `{"hostids":["1"]}`, nil
+		})
+		if err := api.HostsUpdate(zapi.Hosts{{HostID: "1", Host: "a", ProxyID: c.proxyID, MonitoredBy: c.monitoredBy}}); err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range []string{"proxy_hostid", "proxyid", "monitored_by"} {
+			if sent[0][key] != c.expected[key] {
+				t.Errorf("Zabbix %s, proxy %q, monitored by %v: %s is %v instead of %v", c.version, c.proxyID, c.monitoredBy, key, sent[0][key], c.expected[key])
+			}
+		}
+	}
+}
+
+func TestProxyGroups(t *testing.T) {
+	skipTestIfVersionLessThan(t, "7.0", "introduced support for proxy groups")
+
+	api := testGetAPI(t)
+
+	groups := zapi.ProxyGroups{{Name: fmt.Sprintf("zabbix-testing-%d", rand.Int()), FailoverDelay: "1m", MinOnline: "1"}}
+	if err := api.ProxyGroupsCreate(groups); err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := api.ProxyGroupGetByID(groups[0].ProxyGroupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.Name != groups[0].Name {
+		t.Errorf("Bad proxy group %#v", group)
+	}
+
+	group.Description = "updated"
+	if err = api.ProxyGroupsUpdate(zapi.ProxyGroups{*group}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = api.ProxyGroupsDelete(groups); err != nil {
+		t.Fatal(err)
+	}
+}
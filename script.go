@@ -0,0 +1,409 @@
+package zabbix
+
+import "errors"
+
+type (
+	// ScriptType type of a global script
+	// see "type" in https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/object
+	ScriptType int
+
+	// ScriptScope where a global script can be used, new in 5.4
+	// see "scope" in https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/object
+	ScriptScope int
+
+	// ManualInputValidatorType how the manual input of a script is validated, new in 7.0
+	// see "manualinput_validator_type" in https://www.zabbix.com/documentation/7.0/en/manual/api/reference/script/object
+	ManualInputValidatorType int
+)
+
+const (
+	// CustomScriptType (default) custom script
+	CustomScriptType ScriptType = 0
+	// IPMIScriptType IPMI command
+	IPMIScriptType ScriptType = 1
+	// SSHScriptType SSH command (new in 5.4)
+	SSHScriptType ScriptType = 2
+	// TelnetScriptType Telnet command (new in 5.4)
+	TelnetScriptType ScriptType = 3
+	// WebhookScriptType webhook (new in 5.4)
+	WebhookScriptType ScriptType = 5
+	// URLScriptType URL opened by the frontend (new in 7.0)
+	URLScriptType ScriptType = 6
+)
+
+const (
+	// ActionOperationScope script used in action operations
+	ActionOperationScope ScriptScope = 1
+	// ManualHostActionScope script run by hand on hosts
+	ManualHostActionScope ScriptScope = 2
+	// ManualEventActionScope script run by hand on events
+	ManualEventActionScope ScriptScope = 4
+)
+
+const (
+	// RegexValidator (default) manual input matching a regular expression
+	RegexValidator ManualInputValidatorType = 0
+	// ListValidator manual input chosen from a comma separated list
+	ListValidator ManualInputValidatorType = 1
+)
+
+// MarshalJSON encodes t as a string.
+func (t ScriptType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ScriptType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var scriptTypeNames = enumNames{
+	kind: "script type",
+	names: map[int][]string{
+		int(CustomScriptType):  {"Script", "Custom script"},
+		int(IPMIScriptType):    {"IPMI"},
+		int(SSHScriptType):     {"SSH"},
+		int(TelnetScriptType):  {"Telnet"},
+		int(WebhookScriptType): {"Webhook"},
+		int(URLScriptType):     {"URL"},
+	},
+}
+
+func (t ScriptType) String() string {
+	return scriptTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ScriptType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ScriptType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseScriptType(string(text))
+	return
+}
+
+// ParseScriptType Parses a script type from its display name or number.
+func ParseScriptType(s string) (ScriptType, error) {
+	v, err := scriptTypeNames.parse(s)
+	return ScriptType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t ScriptScope) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ScriptScope) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var scriptScopeNames = enumNames{
+	kind: "script scope",
+	names: map[int][]string{
+		int(ActionOperationScope):   {"Action operation"},
+		int(ManualHostActionScope):  {"Manual host action"},
+		int(ManualEventActionScope): {"Manual event action"},
+	},
+}
+
+func (t ScriptScope) String() string {
+	return scriptScopeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ScriptScope) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ScriptScope) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseScriptScope(string(text))
+	return
+}
+
+// ParseScriptScope Parses a script scope from its display name or number.
+func ParseScriptScope(s string) (ScriptScope, error) {
+	v, err := scriptScopeNames.parse(s)
+	return ScriptScope(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t ManualInputValidatorType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ManualInputValidatorType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var manualInputValidatorTypeNames = enumNames{
+	kind: "manual input validator",
+	names: map[int][]string{
+		int(RegexValidator): {"String", "Regular expression"},
+		int(ListValidator):  {"List"},
+	},
+}
+
+func (t ManualInputValidatorType) String() string {
+	return manualInputValidatorTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ManualInputValidatorType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ManualInputValidatorType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseManualInputValidatorType(string(text))
+	return
+}
+
+// ParseManualInputValidatorType Parses a manual input validator from its display name or number.
+func ParseManualInputValidatorType(s string) (ManualInputValidatorType, error) {
+	v, err := manualInputValidatorTypeNames.parse(s)
+	return ManualInputValidatorType(v), err
+}
+
+// ScriptParameter represent a webhook script parameter, new in 5.4
+// https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/object#webhook-parameters
+type ScriptParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ScriptParameters is an array of ScriptParameter
+type ScriptParameters []ScriptParameter
+
+// Script represent Zabbix global script object
+// https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/object
+type Script struct {
+	ScriptID string     `json:"scriptid,omitempty"`
+	Name     string     `json:"name"`
+	Type     ScriptType `json:"type"`
+	// Command is the command to run, or the script of webhooks.
+	Command     string `json:"command,omitempty"`
+	Description string `json:"description,omitempty"`
+	// ExecuteOn is only used by custom scripts.
+	ExecuteOn *ActionOperationCommandExecutorType `json:"execute_on,omitempty"`
+
+	// Restrictions of manual scripts to a user group, a host group ("0" for all) and an access level.
+	UserGroupID  string         `json:"usrgrpid,omitempty"`
+	GroupID      string         `json:"groupid,omitempty"`
+	HostAccess   PermissionType `json:"host_access,omitempty"`
+	Confirmation string         `json:"confirmation,omitempty"`
+
+	// NOTE: new in 5.4
+	Scope    ScriptScope `json:"scope,omitempty"`
+	MenuPath string      `json:"menu_path,omitempty"`
+
+	// SSH and Telnet scripts
+	Port       string                          `json:"port,omitempty"`
+	AuthType   *ActionOperationCommandAuthType `json:"authtype,omitempty"`
+	Username   string                          `json:"username,omitempty"`
+	Password   string                          `json:"password,omitempty"`
+	PublicKey  string                          `json:"publickey,omitempty"`
+	PrivateKey string                          `json:"privatekey,omitempty"`
+
+	// Webhook scripts
+	// NOTE: new in 5.4
+	Timeout    string           `json:"timeout,omitempty"`
+	Parameters ScriptParameters `json:"parameters,omitempty"`
+
+	// URL scripts
+	// NOTE: new in 7.0
+	URL       string `json:"url,omitempty"`
+	NewWindow *Int   `json:"new_window,omitempty"`
+
+	// Input asked to the user running the script, available as the {MANUALINPUT} macro.
+	// NOTE: new in 7.0
+	ManualInput              Int                      `json:"manualinput,omitempty"`
+	ManualInputPrompt        string                   `json:"manualinput_prompt,omitempty"`
+	ManualInputValidator     string                   `json:"manualinput_validator,omitempty"`
+	ManualInputValidatorType ManualInputValidatorType `json:"manualinput_validator_type,omitempty"`
+	ManualInputDefaultValue  string                   `json:"manualinput_default_value,omitempty"`
+}
+
+// Scripts is an array of Script
+type Scripts []Script
+
+var scriptFields = fieldRules{
+	{Field: "scope", Feature: FeatureScriptScopes},
+	{Field: "menu_path", Feature: FeatureScriptScopes},
+	{Field: "timeout", Feature: FeatureScriptScopes},
+	{Field: "parameters", Feature: FeatureScriptScopes},
+	{Field: "url", Feature: FeatureScriptManualInput},
+	{Field: "new_window", Feature: FeatureScriptManualInput},
+	{Field: "manualinput", Feature: FeatureScriptManualInput},
+	{Field: "manualinput_prompt", Feature: FeatureScriptManualInput},
+	{Field: "manualinput_validator", Feature: FeatureScriptManualInput},
+	{Field: "manualinput_validator_type", Feature: FeatureScriptManualInput},
+	{Field: "manualinput_default_value", Feature: FeatureScriptManualInput},
+}
+
+// ScriptsGet Wrapper for script.get
+// https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/get
+func (api *API) ScriptsGet(params Params) (res Scripts, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("script.get", params, &res)
+	return
+}
+
+// ScriptGetByID Gets script by Id only if there is exactly 1 matching script.
+func (api *API) ScriptGetByID(id string) (res *Script, err error) {
+	scripts, err := api.ScriptsGet(Params{"scriptids": id})
+	if err != nil {
+		return
+	}
+
+	if len(scripts) == 1 {
+		res = &scripts[0]
+	} else {
+		e := ExpectedOneResult(len(scripts))
+		err = &e
+	}
+	return
+}
+
+// ScriptsCreate Wrapper for script.create
+// https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/create
+func (api *API) ScriptsCreate(scripts Scripts) (err error) {
+	params, err := api.marshalFor(scripts, scriptFields)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("script.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "scriptids") {
+		scripts[i].ScriptID = id
+	}
+	return
+}
+
+// ScriptsUpdate Wrapper for script.update
+// https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/update
+func (api *API) ScriptsUpdate(scripts Scripts) (err error) {
+	params, err := api.marshalFor(scripts, scriptFields)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("script.update", params)
+	return
+}
+
+// ScriptsDelete Wrapper for script.delete
+// Cleans ScriptID in all scripts elements if call succeed.
+// https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/delete
+func (api *API) ScriptsDelete(scripts Scripts) (err error) {
+	ids := make([]string, len(scripts))
+	for i, script := range scripts {
+		ids[i] = script.ScriptID
+	}
+
+	err = api.ScriptsDeleteByIds(ids)
+	if err == nil {
+		for i := range scripts {
+			scripts[i].ScriptID = ""
+		}
+	}
+	return
+}
+
+// ScriptsDeleteByIds Wrapper for script.delete
+// https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/delete
+func (api *API) ScriptsDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("script.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "scriptids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// ScriptsGetByHosts Wrapper for script.getscriptsbyhosts
+// Returns the scripts available on each host, by host ID.
+// https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/getscriptsbyhosts
+func (api *API) ScriptsGetByHosts(hostIDs []string) (res map[string]Scripts, err error) {
+	err = api.CallWithErrorParse("script.getscriptsbyhosts", hostIDs, &res)
+	return
+}
+
+// ScriptExecuteRequest describes a script run by script.execute, on a host or, from 5.4, on an event.
+type ScriptExecuteRequest struct {
+	ScriptID string
+	HostID   string
+	EventID  string
+	// ManualInput is the value of the {MANUALINPUT} macro.
+	// NOTE: new in 7.0
+	ManualInput string
+}
+
+// ScriptLog is a log entry of a webhook script
+type ScriptLog struct {
+	Level   Int    `json:"level"`
+	Ms      Int    `json:"ms"`
+	Message string `json:"message"`
+}
+
+// ScriptDebug holds the debug information of a webhook script
+type ScriptDebug struct {
+	Logs []ScriptLog `json:"logs"`
+	// Ms is the duration of the script in milliseconds.
+	Ms Int `json:"ms"`
+}
+
+// ScriptExecution is the result of script.execute
+// https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/execute
+type ScriptExecution struct {
+	// Response is "success" or "failed".
+	Response string `json:"response"`
+	// Value is the output of the script.
+	Value string `json:"value"`
+	// Debug is set for webhooks.
+	// NOTE: new in 5.4
+	Debug *ScriptDebug `json:"debug,omitempty"`
+}
+
+// ScriptExecute Wrapper for script.execute
+// https://www.zabbix.com/documentation/5.4/en/manual/api/reference/script/execute
+func (api *API) ScriptExecute(r ScriptExecuteRequest) (res *ScriptExecution, err error) {
+	params := Params{"scriptid": r.ScriptID}
+	switch {
+	case r.HostID != "" && r.EventID != "":
+		return nil, errors.New("zabbix: a script is executed on a host or on an event, not both")
+	case r.HostID != "":
+		params["hostid"] = r.HostID
+	case r.EventID != "":
+		if err = api.requires(FeatureScriptScopes); err != nil {
+			return
+		}
+		params["eventid"] = r.EventID
+	default:
+		return nil, errors.New("zabbix: a script is executed on a host or on an event")
+	}
+	if r.ManualInput != "" {
+		if err = api.requires(FeatureScriptManualInput); err != nil {
+			return
+		}
+		params["manualinput"] = r.ManualInput
+	}
+
+	var execution ScriptExecution
+	if err = api.CallWithErrorParse("script.execute", params, &execution); err != nil {
+		return
+	}
+	res = &execution
+	return
+}
@@ -0,0 +1,82 @@
+package zabbix_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func testCreateScript(t *testing.T) *zapi.Script {
+	api := testGetAPI(t)
+
+	executeOn := zapi.ServerExecutor
+	scripts := zapi.Scripts{{
+		Name:      fmt.Sprintf("zabbix-testing-%d", rand.Int()),
+		Type:      zapi.CustomScriptType,
+		Command:   "echo {HOST.HOST}",
+		ExecuteOn: &executeOn,
+	}}
+	if api.Supports(zapi.FeatureScriptScopes) {
+		scripts[0].Scope = zapi.ManualHostActionScope
+	}
+	err := api.ScriptsCreate(scripts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &scripts[0]
+}
+
+func testDeleteScript(script *zapi.Script, t *testing.T) {
+	err := testGetAPI(t).ScriptsDelete(zapi.Scripts{*script})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScripts(t *testing.T) {
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	script := testCreateScript(t)
+	if script.ScriptID == "" {
+		t.Errorf("Script ID is empty: %#v", script)
+	}
+
+	script2, err := api.ScriptGetByID(script.ScriptID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if script2.Name != script.Name || script2.Command != script.Command || *script2.ExecuteOn != zapi.ServerExecutor {
+		t.Errorf("Scripts are not equal:\n%#v\n%#v", script, script2)
+	}
+
+	byHosts, err := api.ScriptsGetByHosts([]string{host.HostID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, s := range byHosts[host.HostID] {
+		found = found || s.ScriptID == script.ScriptID
+	}
+	if !found {
+		t.Errorf("Script %s not available on host %s: %#v", script.ScriptID, host.HostID, byHosts)
+	}
+
+	script.Description = "updated"
+	if err = api.ScriptsUpdate(zapi.Scripts{*script}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = api.ScriptExecute(zapi.ScriptExecuteRequest{ScriptID: script.ScriptID}); err == nil {
+		t.Error("Expected an error executing a script without host nor event")
+	}
+
+	testDeleteScript(script, t)
+}
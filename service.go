@@ -0,0 +1,516 @@
+package zabbix
+
+import "encoding/json"
+
+type (
+	// ServiceStatus Status of a service, OK or the severity of its problems
+	// see "status" in https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/object
+	ServiceStatus int
+
+	// ServiceAlgorithm How the status of a service is calculated from its child services
+	// see "algorithm" in https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/object
+	ServiceAlgorithm int
+
+	// ServicePropagationRule How the status of a service is propagated to its parent services
+	// see "propagation_rule" in https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/object
+	ServicePropagationRule int
+
+	// ServiceStatusRuleType Condition of a service status rule
+	// see "type" in https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/object#status-rule
+	ServiceStatusRuleType int
+)
+
+// ServiceOK status of services without problem, other statuses being severities such as ServiceStatus(High)
+const ServiceOK ServiceStatus = -1
+
+const (
+	// ServiceAlwaysOK set status to OK
+	ServiceAlwaysOK ServiceAlgorithm = 0
+	// ServiceMostCriticalIfAll most critical status if all child services have problems
+	ServiceMostCriticalIfAll ServiceAlgorithm = 1
+	// ServiceMostCriticalOfChildren most critical status of the child services
+	ServiceMostCriticalOfChildren ServiceAlgorithm = 2
+)
+
+const (
+	// PropagateAsIs (default) propagate the status as is
+	PropagateAsIs ServicePropagationRule = 0
+	// PropagateIncrease increase the severity by the propagation value
+	PropagateIncrease ServicePropagationRule = 1
+	// PropagateDecrease decrease the severity by the propagation value
+	PropagateDecrease ServicePropagationRule = 2
+	// PropagateIgnore ignore the service
+	PropagateIgnore ServicePropagationRule = 3
+	// PropagateFixed propagate the status given by the propagation value
+	PropagateFixed ServicePropagationRule = 4
+)
+
+const (
+	// RuleAtLeastCount at least LimitValue child services have LimitStatus status or above
+	RuleAtLeastCount ServiceStatusRuleType = 0
+	// RuleAtLeastPercent at least LimitValue% of child services have LimitStatus status or above
+	RuleAtLeastPercent ServiceStatusRuleType = 1
+	// RuleLessThanCount less than LimitValue child services have LimitStatus status or below
+	RuleLessThanCount ServiceStatusRuleType = 2
+	// RuleLessThanPercent less than LimitValue% of child services have LimitStatus status or below
+	RuleLessThanPercent ServiceStatusRuleType = 3
+	// RuleWeightAtLeast weight of child services with LimitStatus status or above is at least LimitValue
+	RuleWeightAtLeast ServiceStatusRuleType = 4
+	// RuleWeightPercentAtLeast weight of child services with LimitStatus status or above is at least LimitValue%
+	RuleWeightPercentAtLeast ServiceStatusRuleType = 5
+	// RuleWeightLessThan weight of child services with LimitStatus status or below is less than LimitValue
+	RuleWeightLessThan ServiceStatusRuleType = 6
+	// RuleWeightPercentLessThan weight of child services with LimitStatus status or below is less than LimitValue%
+	RuleWeightPercentLessThan ServiceStatusRuleType = 7
+)
+
+// MarshalJSON encodes t as a string.
+func (t ServiceStatus) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ServiceStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var serviceStatusNames = enumNames{
+	kind: "service status",
+	names: map[int][]string{
+		int(ServiceOK):     {"OK"},
+		int(NotClassified): {"Not classified"},
+		int(Information):   {"Information"},
+		int(Warning):       {"Warning"},
+		int(Average):       {"Average"},
+		int(High):          {"High"},
+		int(Critical):      {"Disaster", "Critical"},
+	},
+}
+
+func (t ServiceStatus) String() string {
+	return serviceStatusNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ServiceStatus) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ServiceStatus) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseServiceStatus(string(text))
+	return
+}
+
+// ParseServiceStatus Parses a service status from its display name or number.
+func ParseServiceStatus(s string) (ServiceStatus, error) {
+	v, err := serviceStatusNames.parse(s)
+	return ServiceStatus(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t ServiceAlgorithm) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ServiceAlgorithm) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var serviceAlgorithmNames = enumNames{
+	kind: "status calculation rule",
+	names: map[int][]string{
+		int(ServiceAlwaysOK):               {"Set status to OK"},
+		int(ServiceMostCriticalIfAll):      {"Most critical if all children have problems"},
+		int(ServiceMostCriticalOfChildren): {"Most critical of child services"},
+	},
+}
+
+func (t ServiceAlgorithm) String() string {
+	return serviceAlgorithmNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ServiceAlgorithm) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ServiceAlgorithm) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseServiceAlgorithm(string(text))
+	return
+}
+
+// ParseServiceAlgorithm Parses a status calculation rule from its display name or number.
+func ParseServiceAlgorithm(s string) (ServiceAlgorithm, error) {
+	v, err := serviceAlgorithmNames.parse(s)
+	return ServiceAlgorithm(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t ServicePropagationRule) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ServicePropagationRule) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var servicePropagationRuleNames = enumNames{
+	kind: "propagation rule",
+	names: map[int][]string{
+		int(PropagateAsIs):     {"As is"},
+		int(PropagateIncrease): {"Increase by"},
+		int(PropagateDecrease): {"Decrease by"},
+		int(PropagateIgnore):   {"Ignore this service"},
+		int(PropagateFixed):    {"Fixed status"},
+	},
+}
+
+func (t ServicePropagationRule) String() string {
+	return servicePropagationRuleNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ServicePropagationRule) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ServicePropagationRule) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseServicePropagationRule(string(text))
+	return
+}
+
+// ParseServicePropagationRule Parses a propagation rule from its display name or number.
+func ParseServicePropagationRule(s string) (ServicePropagationRule, error) {
+	v, err := servicePropagationRuleNames.parse(s)
+	return ServicePropagationRule(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t ServiceStatusRuleType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ServiceStatusRuleType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var serviceStatusRuleTypeNames = enumNames{
+	kind: "status rule type",
+	names: map[int][]string{
+		int(RuleAtLeastCount):          {"If at least N child services have Status status or above"},
+		int(RuleAtLeastPercent):        {"If at least N% of child services have Status status or above"},
+		int(RuleLessThanCount):         {"If less than N child services have Status status or below"},
+		int(RuleLessThanPercent):       {"If less than N% of child services have Status status or below"},
+		int(RuleWeightAtLeast):         {"If weight of child services with Status status or above is at least W"},
+		int(RuleWeightPercentAtLeast):  {"If weight of child services with Status status or above is at least N%"},
+		int(RuleWeightLessThan):        {"If weight of child services with Status status or below is less than W"},
+		int(RuleWeightPercentLessThan): {"If weight of child services with Status status or below is less than N%"},
+	},
+}
+
+func (t ServiceStatusRuleType) String() string {
+	return serviceStatusRuleTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ServiceStatusRuleType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ServiceStatusRuleType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseServiceStatusRuleType(string(text))
+	return
+}
+
+// ParseServiceStatusRuleType Parses a status rule type from its display name or number.
+func ParseServiceStatusRuleType(s string) (ServiceStatusRuleType, error) {
+	v, err := serviceStatusRuleTypeNames.parse(s)
+	return ServiceStatusRuleType(v), err
+}
+
+// ServiceTagFilter is a tag condition of a service problem tag or of an SLA service tag
+type ServiceTagFilter struct {
+	Tag      string                 `json:"tag"`
+	Operator MaintenanceTagOperator `json:"operator"`
+	Value    string                 `json:"value,omitempty"`
+}
+
+// ServiceTagFilters is an array of ServiceTagFilter
+type ServiceTagFilters []ServiceTagFilter
+
+// ServiceStatusRule represent Zabbix service status rule object, setting the status of a service from its child services
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/object#status-rule
+type ServiceStatusRule struct {
+	Type        ServiceStatusRuleType `json:"type"`
+	LimitValue  Int                   `json:"limit_value"`  // count, percentage or weight
+	LimitStatus ServiceStatus         `json:"limit_status"` // status of the child services
+	NewStatus   ServiceStatus         `json:"new_status"`   // severity set to the service
+}
+
+// ServiceStatusRules is an array of ServiceStatusRule
+type ServiceStatusRules []ServiceStatusRule
+
+// ServiceID represent Zabbix ServiceID
+type ServiceID struct {
+	ServiceID string `json:"serviceid"`
+}
+
+// ServiceIDs is an array of ServiceID
+type ServiceIDs []ServiceID
+
+// Service represent Zabbix service object, new in 6.0
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/object
+type Service struct {
+	ServiceID        string                 `json:"serviceid,omitempty"` // Readonly
+	Name             string                 `json:"name"`                // Required
+	Algorithm        ServiceAlgorithm       `json:"algorithm"`           // Required
+	SortOrder        Int                    `json:"sortorder"`           // Required, from 0 to 999
+	Weight           Int                    `json:"weight,omitempty"`
+	PropagationRule  ServicePropagationRule `json:"propagation_rule,omitempty"`
+	PropagationValue Int                    `json:"propagation_value,omitempty"` // severity steps, or status for PropagateFixed
+	Status           ServiceStatus          `json:"status,omitempty"`            // Readonly
+	Description      string                 `json:"description,omitempty"`
+
+	// Fields below are returned with selectTags, selectProblemTags, selectStatusRules, selectParents and selectChildren.
+	Tags        Tags               `json:"tags,omitempty"`
+	ProblemTags ServiceTagFilters  `json:"problem_tags,omitempty"` // problems mapped to the service
+	StatusRules ServiceStatusRules `json:"status_rules,omitempty"`
+	Parents     ServiceIDs         `json:"parents,omitempty"`
+	Children    ServiceIDs         `json:"children,omitempty"`
+}
+
+// Services is an array of Service
+type Services []Service
+
+// MarshalJSON omits the read-only status, so that fetched services can be updated.
+func (s Service) MarshalJSON() ([]byte, error) {
+	type service Service
+	s.Status = 0
+	return json.Marshal(service(s))
+}
+
+// Propagated Returns the status propagated to the parents of the service when it has the given status,
+// according to its propagation rule. OK is always propagated as is, and severities stay within their range.
+func (s Service) Propagated(status ServiceStatus) ServiceStatus {
+	if status == ServiceOK {
+		return status
+	}
+	switch s.PropagationRule {
+	case PropagateIncrease:
+		status += ServiceStatus(s.PropagationValue)
+	case PropagateDecrease:
+		status -= ServiceStatus(s.PropagationValue)
+	case PropagateIgnore:
+		return ServiceOK
+	case PropagateFixed:
+		return ServiceStatus(s.PropagationValue)
+	}
+	if status < ServiceStatus(NotClassified) {
+		status = ServiceStatus(NotClassified)
+	} else if status > ServiceStatus(Critical) {
+		status = ServiceStatus(Critical)
+	}
+	return status
+}
+
+// childIDs returns the IDs of the child services of each service, known from either its children or the parents of the others.
+func (services Services) childIDs() map[string][]string {
+	res := make(map[string][]string)
+	seen := make(map[[2]string]bool)
+	add := func(parent, child string) {
+		if !seen[[2]string{parent, child}] {
+			seen[[2]string{parent, child}] = true
+			res[parent] = append(res[parent], child)
+		}
+	}
+	for _, s := range services {
+		for _, c := range s.Children {
+			add(s.ServiceID, c.ServiceID)
+		}
+		for _, p := range s.Parents {
+			add(p.ServiceID, s.ServiceID)
+		}
+	}
+	return res
+}
+
+// byID returns the index of each service.
+func (services Services) byID() map[string]int {
+	res := make(map[string]int, len(services))
+	for i, s := range services {
+		res[s.ServiceID] = i
+	}
+	return res
+}
+
+// ChildrenOf Returns the child services of a service, among the services.
+// Services must be fetched with selectChildren or selectParents.
+func (services Services) ChildrenOf(id string) (res Services) {
+	index := services.byID()
+	for _, c := range services.childIDs()[id] {
+		if i, ok := index[c]; ok {
+			res = append(res, services[i])
+		}
+	}
+	return
+}
+
+// ParentsOf Returns the parent services of a service, among the services.
+// Services must be fetched with selectChildren or selectParents.
+func (services Services) ParentsOf(id string) (res Services) {
+	children := services.childIDs()
+	for _, s := range services {
+		for _, c := range children[s.ServiceID] {
+			if c == id {
+				res = append(res, s)
+				break
+			}
+		}
+	}
+	return
+}
+
+// Roots Returns the services without parent.
+// Services must be fetched with selectChildren or selectParents.
+func (services Services) Roots() (res Services) {
+	hasParent := make(map[string]bool)
+	for _, children := range services.childIDs() {
+		for _, c := range children {
+			hasParent[c] = true
+		}
+	}
+	for _, s := range services {
+		if !hasParent[s.ServiceID] {
+			res = append(res, s)
+		}
+	}
+	return
+}
+
+// Walk Calls f for the service and its descendants among the services, depth first, with their depth below the service.
+// A service reached several times is only visited the first time.
+func (services Services) Walk(id string, f func(s Service, depth int)) {
+	index := services.byID()
+	children := services.childIDs()
+	visited := make(map[string]bool)
+	var walk func(id string, depth int)
+	walk = func(id string, depth int) {
+		i, ok := index[id]
+		if !ok || visited[id] {
+			return
+		}
+		visited[id] = true
+		f(services[i], depth)
+		for _, c := range children[id] {
+			walk(c, depth+1)
+		}
+	}
+	walk(id, 0)
+}
+
+// ServicesGet Wrapper for service.get
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/get
+func (api *API) ServicesGet(params Params) (res Services, err error) {
+	if err = api.requires(FeatureServices); err != nil {
+		return
+	}
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("service.get", params, &res)
+	return
+}
+
+// ServiceGetByID Gets service by Id only if there is exactly 1 matching service.
+// Its tags, problem tags, status rules, parents and children are selected.
+func (api *API) ServiceGetByID(id string) (res *Service, err error) {
+	services, err := api.ServicesGet(Params{
+		"serviceids":        id,
+		"selectTags":        "extend",
+		"selectProblemTags": "extend",
+		"selectStatusRules": "extend",
+		"selectParents":     []string{"serviceid"},
+		"selectChildren":    []string{"serviceid"},
+	})
+	if err != nil {
+		return
+	}
+
+	if len(services) == 1 {
+		res = &services[0]
+	} else {
+		e := ExpectedOneResult(len(services))
+		err = &e
+	}
+	return
+}
+
+// ServicesCreate Wrapper for service.create
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/create
+func (api *API) ServicesCreate(services Services) (err error) {
+	if err = api.requires(FeatureServices); err != nil {
+		return
+	}
+	response, err := api.CallWithError("service.create", services)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "serviceids") {
+		services[i].ServiceID = id
+	}
+	return
+}
+
+// ServicesUpdate Wrapper for service.update
+// Tags, problem tags, status rules, parents and children given replace those of the service.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/update
+func (api *API) ServicesUpdate(services Services) (err error) {
+	if err = api.requires(FeatureServices); err != nil {
+		return
+	}
+	_, err = api.CallWithError("service.update", services)
+	return
+}
+
+// ServicesDelete Wrapper for service.delete
+// Cleans ServiceID in all services elements if call succeed.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/delete
+func (api *API) ServicesDelete(services Services) (err error) {
+	ids := make([]string, len(services))
+	for i, service := range services {
+		ids[i] = service.ServiceID
+	}
+
+	err = api.ServicesDeleteByIds(ids)
+	if err == nil {
+		for i := range services {
+			services[i].ServiceID = ""
+		}
+	}
+	return
+}
+
+// ServicesDeleteByIds Wrapper for service.delete
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/service/delete
+func (api *API) ServicesDeleteByIds(ids []string) (err error) {
+	if err = api.requires(FeatureServices); err != nil {
+		return
+	}
+	response, err := api.CallWithError("service.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "serviceids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
@@ -0,0 +1,139 @@
+package zabbix_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestServicePropagation(t *testing.T) {
+	high := zapi.ServiceStatus(zapi.High)
+	for _, c := range []struct {
+		rule     zapi.ServicePropagationRule
+		value    zapi.Int
+		status   zapi.ServiceStatus
+		expected zapi.ServiceStatus
+	}{
+		{zapi.PropagateAsIs, 0, high, high},
+		{zapi.PropagateAsIs, 0, zapi.ServiceOK, zapi.ServiceOK},
+		{zapi.PropagateIncrease, 1, high, zapi.ServiceStatus(zapi.Critical)},
+		{zapi.PropagateIncrease, 3, high, zapi.ServiceStatus(zapi.Critical)},
+		{zapi.PropagateDecrease, 2, high, zapi.ServiceStatus(zapi.Warning)},
+		{zapi.PropagateDecrease, 5, high, zapi.ServiceStatus(zapi.NotClassified)},
+		{zapi.PropagateIgnore, 0, high, zapi.ServiceOK},
+		{zapi.PropagateFixed, 2, high, zapi.ServiceStatus(zapi.Warning)},
+		{zapi.PropagateFixed, 2, zapi.ServiceOK, zapi.ServiceOK},
+	} {
+		s := zapi.Service{PropagationRule: c.rule, PropagationValue: c.value}
+		if status := s.Propagated(c.status); status != c.expected {
+			t.Errorf("%s %d of %s is %s instead of %s", c.rule, c.value, c.status, status, c.expected)
+		}
+	}
+}
+
+func TestServiceTree(t *testing.T) {
+	// root -> a -> c, root -> b, b known from its parent only, d alone
+	services := zapi.Services{
+		{ServiceID: "1", Name: "root", Children: zapi.ServiceIDs{{ServiceID: "2"}}},
+		{ServiceID: "2", Name: "a", Children: zapi.ServiceIDs{{ServiceID: "4"}}},
+		{ServiceID: "3", Name: "b", Parents: zapi.ServiceIDs{{ServiceID: "1"}}},
+		{ServiceID: "4", Name: "c", Parents: zapi.ServiceIDs{{ServiceID: "2"}}},
+		{ServiceID: "5", Name: "d"},
+	}
+	names := func(services zapi.Services) (res []string) {
+		for _, s := range services {
+			res = append(res, s.Name)
+		}
+		return
+	}
+
+	if roots := names(services.Roots()); !reflect.DeepEqual(roots, []string{"root", "d"}) {
+		t.Errorf("Unexpected roots %v", roots)
+	}
+	if children := names(services.ChildrenOf("1")); !reflect.DeepEqual(children, []string{"a", "b"}) {
+		t.Errorf("Unexpected children %v", children)
+	}
+	if parents := names(services.ParentsOf("4")); !reflect.DeepEqual(parents, []string{"a"}) {
+		t.Errorf("Unexpected parents %v", parents)
+	}
+	var walked []string
+	services.Walk("1", func(s zapi.Service, depth int) {
+		walked = append(walked, fmt.Sprintf("%d:%s", depth, s.Name))
+	})
+	if !reflect.DeepEqual(walked, []string{"0:root", "1:a", "2:c", "1:b"}) {
+		t.Errorf("Unexpected walk %v", walked)
+	}
+}
+
+func TestServices(t *testing.T) {
+	skipTestIfVersionLessThan(t, "6.0", "Services are new in 6.0")
+	api := testGetAPI(t)
+
+	name := fmt.Sprintf("zabbix-testing-%d", rand.Int())
+	services := zapi.Services{{
+		Name:      name,
+		Algorithm: zapi.ServiceMostCriticalOfChildren,
+		StatusRules: zapi.ServiceStatusRules{{
+			Type:        zapi.RuleAtLeastPercent,
+			LimitValue:  50,
+			LimitStatus: zapi.ServiceStatus(zapi.Warning),
+			NewStatus:   zapi.ServiceStatus(zapi.High),
+		}},
+	}}
+	err := api.ServicesCreate(services)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent := &services[0]
+
+	children := zapi.Services{{
+		Name:             name + "-child",
+		Algorithm:        zapi.ServiceAlwaysOK,
+		PropagationRule:  zapi.PropagateIncrease,
+		PropagationValue: 1,
+		Parents:          zapi.ServiceIDs{{ServiceID: parent.ServiceID}},
+		Tags:             zapi.Tags{{Tag: "team", Value: "ops"}},
+		ProblemTags:      zapi.ServiceTagFilters{{Tag: "service", Operator: zapi.TagEquals, Value: name}},
+	}}
+	err = api.ServicesCreate(children)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.ServicesDelete(children)
+
+	parent2, err := api.ServiceGetByID(parent.ServiceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent2.Name != name || parent2.Status != zapi.ServiceOK || !reflect.DeepEqual(parent2.StatusRules, parent.StatusRules) {
+		t.Errorf("Services are not equal:\n%#v\n%#v", parent, parent2)
+	}
+	if len(parent2.Children) != 1 || parent2.Children[0].ServiceID != children[0].ServiceID {
+		t.Errorf("Unexpected children: %#v", parent2.Children)
+	}
+
+	child, err := api.ServiceGetByID(children[0].ServiceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(child.Tags, children[0].Tags) || !reflect.DeepEqual(child.ProblemTags, children[0].ProblemTags) {
+		t.Errorf("Unexpected tags: %#v", child)
+	}
+
+	parent2.Description = "updated"
+	err = api.ServicesUpdate(zapi.Services{*parent2})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = api.ServicesDelete(services)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent.ServiceID != "" {
+		t.Errorf("Service ID is not cleaned: %#v", parent)
+	}
+}
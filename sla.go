@@ -0,0 +1,386 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type (
+	// SLAPeriod Reporting period of an SLA
+	// see "period" in https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/object
+	SLAPeriod int
+
+	// SLAStatus Status of an SLA
+	// see "status" in https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/object
+	SLAStatus int
+)
+
+const (
+	// SLADaily daily
+	SLADaily SLAPeriod = 0
+	// SLAWeekly weekly
+	SLAWeekly SLAPeriod = 1
+	// SLAMonthly monthly
+	SLAMonthly SLAPeriod = 2
+	// SLAQuarterly quarterly
+	SLAQuarterly SLAPeriod = 3
+	// SLAAnnually annually
+	SLAAnnually SLAPeriod = 4
+)
+
+const (
+	// SLADisabled disabled SLA
+	SLADisabled SLAStatus = 0
+	// SLAEnabled enabled SLA
+	SLAEnabled SLAStatus = 1
+)
+
+// MarshalJSON encodes t as a string.
+func (t SLAPeriod) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *SLAPeriod) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var slaPeriodNames = enumNames{
+	kind: "SLA period",
+	names: map[int][]string{
+		int(SLADaily):     {"Daily"},
+		int(SLAWeekly):    {"Weekly"},
+		int(SLAMonthly):   {"Monthly"},
+		int(SLAQuarterly): {"Quarterly"},
+		int(SLAAnnually):  {"Annually"},
+	},
+}
+
+func (t SLAPeriod) String() string {
+	return slaPeriodNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t SLAPeriod) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *SLAPeriod) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseSLAPeriod(string(text))
+	return
+}
+
+// ParseSLAPeriod Parses an SLA period from its display name or number.
+func ParseSLAPeriod(s string) (SLAPeriod, error) {
+	v, err := slaPeriodNames.parse(s)
+	return SLAPeriod(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t SLAStatus) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *SLAStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var slaStatusNames = enumNames{
+	kind: "SLA status",
+	names: map[int][]string{
+		int(SLADisabled): {"Disabled"},
+		int(SLAEnabled):  {"Enabled"},
+	},
+}
+
+func (t SLAStatus) String() string {
+	return slaStatusNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t SLAStatus) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *SLAStatus) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseSLAStatus(string(text))
+	return
+}
+
+// ParseSLAStatus Parses an SLA status from its display name or number.
+func ParseSLAStatus(s string) (SLAStatus, error) {
+	v, err := slaStatusNames.parse(s)
+	return SLAStatus(v), err
+}
+
+// SLASchedule represent Zabbix SLA schedule object, a weekly period of service
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/object#sla-schedule
+type SLASchedule struct {
+	PeriodFrom Int `json:"period_from"` // seconds since Sunday 00:00
+	PeriodTo   Int `json:"period_to"`   // seconds since Sunday 00:00, up to a week
+}
+
+// SLASchedules is an array of SLASchedule
+type SLASchedules []SLASchedule
+
+// NewSLASchedule Builds the schedule of the week periods, such as those returned by ParseWeekPeriods.
+func NewSLASchedule(periods ...WeekPeriod) (res SLASchedules) {
+	const day = 24 * 60 * 60
+	for _, p := range periods {
+		for d := p.FromDay; d <= p.ToDay; d++ {
+			start := d % 7 * day // Sunday is 7 in periods and the start of the week in schedules
+			res = append(res, SLASchedule{
+				PeriodFrom: Int(start + int(p.From/time.Second)),
+				PeriodTo:   Int(start + int(p.To/time.Second)),
+			})
+		}
+	}
+	return
+}
+
+// SLAExcludedDowntime represent Zabbix SLA excluded downtime object, a period not counted by the SLA
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/object#sla-excluded-downtime
+type SLAExcludedDowntime struct {
+	Name       string    `json:"name"`
+	PeriodFrom Timestamp `json:"period_from"`
+	PeriodTo   Timestamp `json:"period_to"`
+}
+
+// SLAExcludedDowntimes is an array of SLAExcludedDowntime
+type SLAExcludedDowntimes []SLAExcludedDowntime
+
+// SLA represent Zabbix SLA object, new in 6.0
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/object
+type SLA struct {
+	SLAID         string     `json:"slaid,omitempty"` // Readonly
+	Name          string     `json:"name"`            // Required
+	Period        SLAPeriod  `json:"period"`          // Required
+	SLO           Number     `json:"slo"`             // Required, percentage
+	EffectiveDate *Timestamp `json:"effective_date,omitempty"`
+	Timezone      string     `json:"timezone,omitempty"` // such as "Europe/Riga", the server one by default
+	Status        SLAStatus  `json:"status"`
+	Description   string     `json:"description,omitempty"`
+
+	// Fields below are returned with selectServiceTags, selectSchedule and selectExcludedDowntimes.
+	ServiceTags       ServiceTagFilters    `json:"service_tags,omitempty"` // Required, services covered by the SLA
+	Schedule          SLASchedules         `json:"schedule,omitempty"`     // always by default
+	ExcludedDowntimes SLAExcludedDowntimes `json:"excluded_downtimes,omitempty"`
+}
+
+// SLAs is an array of SLA
+type SLAs []SLA
+
+// SLAsGet Wrapper for sla.get
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/get
+func (api *API) SLAsGet(params Params) (res SLAs, err error) {
+	if err = api.requires(FeatureServices); err != nil {
+		return
+	}
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("sla.get", params, &res)
+	return
+}
+
+// SLAGetByID Gets SLA by Id only if there is exactly 1 matching SLA.
+// Its service tags, schedule and excluded downtimes are selected.
+func (api *API) SLAGetByID(id string) (res *SLA, err error) {
+	slas, err := api.SLAsGet(Params{
+		"slaids":                  id,
+		"selectServiceTags":       "extend",
+		"selectSchedule":          "extend",
+		"selectExcludedDowntimes": "extend",
+	})
+	if err != nil {
+		return
+	}
+
+	if len(slas) == 1 {
+		res = &slas[0]
+	} else {
+		e := ExpectedOneResult(len(slas))
+		err = &e
+	}
+	return
+}
+
+// SLAsCreate Wrapper for sla.create
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/create
+func (api *API) SLAsCreate(slas SLAs) (err error) {
+	if err = api.requires(FeatureServices); err != nil {
+		return
+	}
+	response, err := api.CallWithError("sla.create", slas)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "slaids") {
+		slas[i].SLAID = id
+	}
+	return
+}
+
+// SLAsUpdate Wrapper for sla.update
+// Service tags, schedule and excluded downtimes given replace those of the SLA.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/update
+func (api *API) SLAsUpdate(slas SLAs) (err error) {
+	if err = api.requires(FeatureServices); err != nil {
+		return
+	}
+	_, err = api.CallWithError("sla.update", slas)
+	return
+}
+
+// SLAsDelete Wrapper for sla.delete
+// Cleans SLAID in all SLAs elements if call succeed.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/delete
+func (api *API) SLAsDelete(slas SLAs) (err error) {
+	ids := make([]string, len(slas))
+	for i, sla := range slas {
+		ids[i] = sla.SLAID
+	}
+
+	err = api.SLAsDeleteByIds(ids)
+	if err == nil {
+		for i := range slas {
+			slas[i].SLAID = ""
+		}
+	}
+	return
+}
+
+// SLAsDeleteByIds Wrapper for sla.delete
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/delete
+func (api *API) SLAsDeleteByIds(ids []string) (err error) {
+	if err = api.requires(FeatureServices); err != nil {
+		return
+	}
+	response, err := api.CallWithError("sla.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "slaids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// SLIRequest holds the parameters of sla.getsli, the SLA being required.
+// Without dates, the last periods of the SLA are reported.
+type SLIRequest struct {
+	SLAID      string
+	From       time.Time // beginning of the first period, optional
+	To         time.Time // end of the last period, optional
+	Periods    int       // number of periods, 20 by default and at most 100
+	ServiceIDs []string  // all the services of the SLA by default
+}
+
+// SLIPeriod is a reporting period of an SLA
+type SLIPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+// SLI is the service level indicator of a service during a period
+type SLI struct {
+	Uptime            time.Duration
+	Downtime          time.Duration
+	SLI               float64       // percentage of uptime
+	ErrorBudget       time.Duration // downtime left before the SLO is broken, negative once it is
+	ExcludedDowntimes SLAExcludedDowntimes
+}
+
+// SLIReport is the report of sla.getsli
+type SLIReport struct {
+	Periods    []SLIPeriod
+	ServiceIDs []string
+	// SLI holds the indicators of each period and service, indexed as Periods and ServiceIDs.
+	SLI [][]SLI
+}
+
+// Service Returns the indicators of the service for each period, nil if the service is not in the report.
+func (r *SLIReport) Service(id string) (res []SLI) {
+	for j, s := range r.ServiceIDs {
+		if s != id {
+			continue
+		}
+		res = make([]SLI, len(r.Periods))
+		for i := range r.Periods {
+			if i < len(r.SLI) && j < len(r.SLI[i]) {
+				res[i] = r.SLI[i][j]
+			}
+		}
+		return
+	}
+	return
+}
+
+// sliObject is an indicator as returned by sla.getsli
+type sliObject struct {
+	Uptime            Int                  `json:"uptime"`
+	Downtime          Int                  `json:"downtime"`
+	SLI               json.Number          `json:"sli"`
+	ErrorBudget       Int                  `json:"error_budget"`
+	ExcludedDowntimes SLAExcludedDowntimes `json:"excluded_downtimes"`
+}
+
+// SLAGetSLI Wrapper for sla.getsli
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/sla/getsli
+func (api *API) SLAGetSLI(r SLIRequest) (res *SLIReport, err error) {
+	if err = api.requires(FeatureServices); err != nil {
+		return
+	}
+	params := Params{"slaid": r.SLAID}
+	if !r.From.IsZero() {
+		params["period_from"] = r.From.Unix()
+	}
+	if !r.To.IsZero() {
+		params["period_to"] = r.To.Unix()
+	}
+	if r.Periods > 0 {
+		params["periods"] = r.Periods
+	}
+	if r.ServiceIDs != nil {
+		params["serviceids"] = r.ServiceIDs
+	}
+
+	var result struct {
+		Periods []struct {
+			From Timestamp `json:"period_from"`
+			To   Timestamp `json:"period_to"`
+		} `json:"periods"`
+		ServiceIDs []string      `json:"serviceids"`
+		SLI        [][]sliObject `json:"sli"`
+	}
+	if err = api.CallWithErrorParse("sla.getsli", params, &result); err != nil {
+		return
+	}
+
+	res = &SLIReport{ServiceIDs: result.ServiceIDs, SLI: make([][]SLI, len(result.SLI))}
+	for _, p := range result.Periods {
+		res.Periods = append(res.Periods, SLIPeriod{From: p.From.Time, To: p.To.Time})
+	}
+	for i, period := range result.SLI {
+		res.SLI[i] = make([]SLI, len(period))
+		for j, o := range period {
+			s := SLI{
+				Uptime:            time.Duration(o.Uptime) * time.Second,
+				Downtime:          time.Duration(o.Downtime) * time.Second,
+				ErrorBudget:       time.Duration(o.ErrorBudget) * time.Second,
+				ExcludedDowntimes: o.ExcludedDowntimes,
+			}
+			if s.SLI, err = o.SLI.Float64(); err != nil {
+				return nil, fmt.Errorf("zabbix: bad SLI %q: %v", o.SLI, err)
+			}
+			res.SLI[i][j] = s
+		}
+	}
+	return
+}
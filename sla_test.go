@@ -0,0 +1,102 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestSLASchedule(t *testing.T) {
+	periods, err := zapi.ParseWeekPeriods("5-7,09:00-17:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const day, hour = 86400, 3600
+	expected := zapi.SLASchedules{
+		{PeriodFrom: 5*day + 9*hour, PeriodTo: 5*day + 17*hour + 1800},
+		{PeriodFrom: 6*day + 9*hour, PeriodTo: 6*day + 17*hour + 1800},
+		{PeriodFrom: 9 * hour, PeriodTo: 17*hour + 1800},
+	}
+	if schedule := zapi.NewSLASchedule(periods...); !reflect.DeepEqual(schedule, expected) {
+		t.Errorf("Unexpected schedule:\n%v\n%v", schedule, expected)
+	}
+}
+
+func TestSLADecoding(t *testing.T) {
+	var slas zapi.SLAs
+	if err := json.Unmarshal([]byte(`[{"slaid": "1", "slo": "99.9"}, {"slaid": "2", "slo": 99.9}]`), &slas); err != nil {
+		t.Fatal(err)
+	}
+	for _, sla := range slas {
+		if sla.SLO != 99.9 {
+			t.Errorf("Bad SLO %v of SLA %s", sla.SLO, sla.SLAID)
+		}
+	}
+}
+
+func TestSLAs(t *testing.T) {
+	skipTestIfVersionLessThan(t, "6.0", "SLAs are new in 6.0")
+	api := testGetAPI(t)
+
+	name := fmt.Sprintf("zabbix-testing-%d", rand.Int())
+	services := zapi.Services{{Name: name, Algorithm: zapi.ServiceMostCriticalOfChildren, Tags: zapi.Tags{{Tag: "sla", Value: name}}}}
+	err := api.ServicesCreate(services)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.ServicesDelete(services)
+
+	effective := zapi.NewTimestamp(time.Now().Add(-72 * time.Hour).Truncate(24 * time.Hour))
+	slas := zapi.SLAs{{
+		Name:          name,
+		Period:        zapi.SLADaily,
+		SLO:           99.5,
+		EffectiveDate: &effective,
+		Timezone:      "UTC",
+		Status:        zapi.SLAEnabled,
+		ServiceTags:   zapi.ServiceTagFilters{{Tag: "sla", Operator: zapi.TagEquals, Value: name}},
+	}}
+	err = api.SLAsCreate(slas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sla := &slas[0]
+
+	sla2, err := api.SLAGetByID(sla.SLAID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sla2.Name != name || sla2.SLO != 99.5 || sla2.Status != zapi.SLAEnabled || !reflect.DeepEqual(sla2.ServiceTags, sla.ServiceTags) {
+		t.Errorf("SLAs are not equal:\n%#v\n%#v", sla, sla2)
+	}
+
+	report, err := api.SLAGetSLI(zapi.SLIRequest{SLAID: sla.SLAID, Periods: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Periods) != 2 || len(report.ServiceIDs) != 1 || report.ServiceIDs[0] != services[0].ServiceID {
+		t.Errorf("Unexpected SLI report: %#v", report)
+	}
+	if series := report.Service(services[0].ServiceID); len(series) != 2 {
+		t.Errorf("Unexpected SLI series: %#v", series)
+	}
+
+	sla2.Schedule = zapi.NewSLASchedule(zapi.WeekPeriod{FromDay: 1, ToDay: 5, From: 8 * time.Hour, To: 18 * time.Hour})
+	err = api.SLAsUpdate(zapi.SLAs{*sla2})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = api.SLAsDelete(slas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sla.SLAID != "" {
+		t.Errorf("SLA ID is not cleaned: %#v", sla)
+	}
+}
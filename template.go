@@ -51,18 +51,29 @@ func (api *API) TemplateGetByID(id string) (template *Template, err error) {
 	return
 }
 
+// templateParams returns the parameters of template.create and template.update for the server version.
+func (api *API) templateParams(templates Templates) (params interface{}, err error) {
+	if params, err = api.marshalFor(templates, nil); err != nil {
+		return
+	}
+	err = api.adaptMacros(params)
+	return
+}
+
 // TemplatesCreate Wrapper for template.create
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/template/create
 func (api *API) TemplatesCreate(templates Templates) (err error) {
-	response, err := api.CallWithError("template.create", templates)
+	params, err := api.templateParams(templates)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("template.create", params)
 	if err != nil {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	templateids := result["templateids"].([]interface{})
-	for i, id := range templateids {
-		templates[i].TemplateID = id.(string)
+	for i, id := range resultIDs(response.Result, "templateids") {
+		templates[i].TemplateID = id
 	}
 	return
 }
@@ -70,7 +81,11 @@ func (api *API) TemplatesCreate(templates Templates) (err error) {
 // TemplatesUpdate Wrapper for template.update
 // https://www.zabbix.com/documentation/3.2/manual/api/reference/template/update
 func (api *API) TemplatesUpdate(templates Templates) (err error) {
-	_, err = api.CallWithError("template.update", templates)
+	params, err := api.templateParams(templates)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("template.update", params)
 	return
 }
 
@@ -13,6 +13,9 @@ type TemplateGroups []TemplateGroup
 // TemplateGroupsGet Wrapper for templategroup.get
 // https://www.zabbix.com/documentation/6.2/en/manual/api/reference/templategroup/get
 func (api *API) TemplateGroupsGet(params Params) (res TemplateGroups, err error) {
+	if err = api.requires(FeatureTemplateGroups); err != nil {
+		return
+	}
 	if _, present := params["output"]; !present {
 		params["output"] = "extend"
 	}
@@ -40,15 +43,16 @@ func (api *API) TemplateGroupGetByID(id string) (res *TemplateGroup, err error)
 // TemplateGroupsCreate Wrapper for templategroup.create
 // https://www.zabbix.com/documentation/6.2/en/manual/api/reference/templategroup/create
 func (api *API) TemplateGroupsCreate(TemplateGroups TemplateGroups) (err error) {
+	if err = api.requires(FeatureTemplateGroups); err != nil {
+		return
+	}
 	response, err := api.CallWithError("templategroup.create", TemplateGroups)
 	if err != nil {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	groupids := result["groupids"].([]interface{})
-	for i, id := range groupids {
-		TemplateGroups[i].GroupID = id.(string)
+	for i, id := range resultIDs(response.Result, "groupids") {
+		TemplateGroups[i].GroupID = id
 	}
 	return
 }
@@ -56,6 +60,9 @@ func (api *API) TemplateGroupsCreate(TemplateGroups TemplateGroups) (err error)
 // TemplateGroupsUpdate Wrapper for templategroup.update
 // https://www.zabbix.com/documentation/6.2/en/manual/api/reference/templategroup/update
 func (api *API) TemplateGroupsUpdate(TemplateGroups TemplateGroups) (err error) {
+	if err = api.requires(FeatureTemplateGroups); err != nil {
+		return
+	}
 	_, err = api.CallWithError("templategroup.update", TemplateGroups)
 	return
 }
@@ -81,6 +88,9 @@ func (api *API) TemplateGroupsDelete(TemplateGroups TemplateGroups) (err error)
 // TemplateGroupsDeleteByIds Wrapper for templategroup.delete
 // https://www.zabbix.com/documentation/6.2/en/manual/api/reference/templategroup/delete
 func (api *API) TemplateGroupsDeleteByIds(ids []string) (err error) {
+	if err = api.requires(FeatureTemplateGroups); err != nil {
+		return
+	}
 	response, err := api.CallWithError("templategroup.delete", ids)
 	if err != nil {
 		return
@@ -0,0 +1,508 @@
+package zabbix
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeSyntaxError use to report a malformed time expression, pointing at the offending segment
+type TimeSyntaxError struct {
+	Input   string // whole expression
+	Segment string // offending part of Input
+	Offset  int    // position of Segment in Input
+	Reason  string
+}
+
+func (e *TimeSyntaxError) Error() string {
+	return fmt.Sprintf("Invalid %q at position %d of %q: %s.", e.Segment, e.Offset, e.Input, e.Reason)
+}
+
+var (
+	// user macros, possibly with context, and low-level discovery macros
+	macroRegexp = regexp.MustCompile(`^(\{\$[A-Z0-9_.]+(:.*)?\}|\{#[A-Z0-9_.]+\})$`)
+
+	timeSuffixes = map[byte]time.Duration{
+		's': time.Second,
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+)
+
+func isMacro(s string) bool {
+	return macroRegexp.MatchString(s)
+}
+
+// TimeUnit is a duration in Zabbix syntax: a number of seconds or a number with a time suffix
+// ("30s", "1h", "7d"), or a macro, as used by Item.Delay, Item.History, Action.Period or LLDRule.LifeTime.
+// https://www.zabbix.com/documentation/5.0/manual/appendix/suffixes
+type TimeUnit struct {
+	Value  int64
+	Suffix byte   // one of s, m, h, d and w, or 0 for plain seconds
+	Macro  string // set instead of Value and Suffix for macros
+}
+
+// NewTimeUnit Returns d with the largest time suffix representing it exactly.
+func NewTimeUnit(d time.Duration) TimeUnit {
+	seconds := int64(d / time.Second)
+	for _, suffix := range []byte("wdhm") {
+		n := int64(timeSuffixes[suffix] / time.Second)
+		if seconds != 0 && seconds%n == 0 {
+			return TimeUnit{Value: seconds / n, Suffix: suffix}
+		}
+	}
+	return TimeUnit{Value: seconds, Suffix: 's'}
+}
+
+// ParseTimeUnit Parses a duration such as "30", "30s", "1h", "7d" or "{$DELAY}".
+func ParseTimeUnit(s string) (TimeUnit, error) {
+	return parseTimeUnit(s, s, 0)
+}
+
+func parseTimeUnit(input, s string, offset int) (t TimeUnit, err error) {
+	if isMacro(s) {
+		t.Macro = s
+		return
+	}
+
+	digits := s
+	if n := len(s); n > 0 {
+		if _, ok := timeSuffixes[s[n-1]]; ok {
+			t.Suffix = s[n-1]
+			digits = s[:n-1]
+		}
+	}
+	t.Value, err = strconv.ParseInt(digits, 10, 64)
+	if err != nil || t.Value < 0 || strings.HasPrefix(digits, "+") {
+		err = &TimeSyntaxError{input, s, offset, "expected a positive number with an optional s, m, h, d or w suffix, or a macro"}
+	}
+	return
+}
+
+// IsMacro Tells if the duration is a macro, only known by the server.
+func (t TimeUnit) IsMacro() bool {
+	return t.Macro != ""
+}
+
+// Duration Converts t to a time.Duration, zero for macros.
+func (t TimeUnit) Duration() time.Duration {
+	if t.IsMacro() {
+		return 0
+	}
+	unit := time.Second
+	if t.Suffix != 0 {
+		unit = timeSuffixes[t.Suffix]
+	}
+	return time.Duration(t.Value) * unit
+}
+
+func (t TimeUnit) String() string {
+	if t.IsMacro() {
+		return t.Macro
+	}
+	s := strconv.FormatInt(t.Value, 10)
+	if t.Suffix != 0 {
+		s += string(t.Suffix)
+	}
+	return s
+}
+
+// WeekPeriod is a time period in Zabbix syntax, "1-5,09:00-18:00":
+// a range of week days, from Monday (1) to Sunday (7), and a range of time of day.
+// https://www.zabbix.com/documentation/5.0/manual/appendix/time_period
+type WeekPeriod struct {
+	FromDay int
+	ToDay   int
+	From    time.Duration // since midnight
+	To      time.Duration // since midnight, up to 24h
+}
+
+// ParseWeekPeriod Parses a time period such as "1-5,09:00-18:00" or "7,0:00-24:00".
+func ParseWeekPeriod(s string) (WeekPeriod, error) {
+	return parseWeekPeriod(s, s, 0)
+}
+
+// ParseWeekPeriods Parses time periods separated by semicolons, as used by user media and action conditions.
+func ParseWeekPeriods(s string) (periods []WeekPeriod, err error) {
+	offset := 0
+	for _, part := range strings.Split(s, ";") {
+		if part != "" {
+			var p WeekPeriod
+			if p, err = parseWeekPeriod(s, part, offset); err != nil {
+				return nil, err
+			}
+			periods = append(periods, p)
+		}
+		offset += len(part) + 1
+	}
+	return
+}
+
+// FormatWeekPeriods Formats time periods separated by semicolons.
+func FormatWeekPeriods(periods []WeekPeriod) string {
+	parts := make([]string, len(periods))
+	for i, p := range periods {
+		parts[i] = p.String()
+	}
+	return strings.Join(parts, ";")
+}
+
+func parseWeekPeriod(input, s string, offset int) (p WeekPeriod, err error) {
+	comma := strings.IndexByte(s, ',')
+	if comma < 0 {
+		err = &TimeSyntaxError{input, s, offset, "expected week days and a time range separated by a comma"}
+		return
+	}
+	days, times := s[:comma], s[comma+1:]
+
+	p.FromDay, p.ToDay, err = parseRange(input, days, offset, 1, 7)
+	if err != nil {
+		return
+	}
+
+	dash := strings.IndexByte(times, '-')
+	if dash < 0 {
+		err = &TimeSyntaxError{input, times, offset + comma + 1, "expected a time range such as 09:00-18:00"}
+		return
+	}
+	timesOffset := offset + comma + 1
+	if p.From, err = parseTimeOfDay(input, times[:dash], timesOffset); err != nil {
+		return
+	}
+	if p.To, err = parseTimeOfDay(input, times[dash+1:], timesOffset+dash+1); err != nil {
+		return
+	}
+	if p.From >= p.To {
+		err = &TimeSyntaxError{input, times, timesOffset, "start time must be before end time"}
+	}
+	return
+}
+
+func parseTimeOfDay(input, s string, offset int) (time.Duration, error) {
+	colon := strings.IndexByte(s, ':')
+	if colon > 0 && colon <= 2 && len(s) == colon+3 {
+		h, herr := strconv.Atoi(s[:colon])
+		m, merr := strconv.Atoi(s[colon+1:])
+		if herr == nil && merr == nil && h >= 0 && m >= 0 && m < 60 && (h < 24 || (h == 24 && m == 0)) {
+			return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+		}
+	}
+	return 0, &TimeSyntaxError{input, s, offset, "expected a time of day between 00:00 and 24:00"}
+}
+
+// parseRange parses "n" or "n-m" with lo <= n <= m <= hi.
+func parseRange(input, s string, offset, lo, hi int) (from, to int, err error) {
+	bounds := strings.SplitN(s, "-", 2)
+	from, err = strconv.Atoi(bounds[0])
+	to = from
+	if err == nil && len(bounds) == 2 {
+		to, err = strconv.Atoi(bounds[1])
+	}
+	if err != nil || from < lo || to > hi || from > to || strings.HasPrefix(s, "+") {
+		err = &TimeSyntaxError{input, s, offset, fmt.Sprintf("expected a number or a range between %d and %d", lo, hi)}
+	}
+	return
+}
+
+// Contains Tells if t, in its own location, is within the period.
+func (p WeekPeriod) Contains(t time.Time) bool {
+	day := int(t.Weekday())
+	if day == 0 {
+		day = 7
+	}
+	h, m, s := t.Clock()
+	since := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	return day >= p.FromDay && day <= p.ToDay && since >= p.From && since < p.To
+}
+
+func (p WeekPeriod) String() string {
+	days := strconv.Itoa(p.FromDay)
+	if p.ToDay != p.FromDay {
+		days += "-" + strconv.Itoa(p.ToDay)
+	}
+	return fmt.Sprintf("%s,%s-%s", days, formatTimeOfDay(p.From), formatTimeOfDay(p.To))
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// Delay is an item update interval with its custom intervals, as in Item.Delay:
+// "30s;50s/1-5,09:00-18:00;md1wd1h9".
+// https://www.zabbix.com/documentation/5.0/manual/config/items/item/custom_intervals
+type Delay struct {
+	Interval TimeUnit
+	Custom   []CustomInterval
+}
+
+// CustomInterval is either a flexible interval, a scheduling interval or a macro
+type CustomInterval struct {
+	Flexible   *FlexibleInterval
+	Scheduling *SchedulingInterval
+	Macro      string
+}
+
+// FlexibleInterval is an update interval used during a time period, "50s/1-5,09:00-18:00"
+type FlexibleInterval struct {
+	Interval TimeUnit
+	Period   WeekPeriod
+}
+
+// ParseDelay Parses an update interval with its custom intervals.
+func ParseDelay(s string) (d Delay, err error) {
+	parts := strings.Split(s, ";")
+	if d.Interval, err = parseTimeUnit(s, parts[0], 0); err != nil {
+		return
+	}
+
+	offset := len(parts[0]) + 1
+	for _, part := range parts[1:] {
+		var custom CustomInterval
+		switch {
+		case part == "":
+			// Zabbix tolerates empty custom intervals
+		case isMacro(part):
+			custom.Macro = part
+		case part[0] >= 'a' && part[0] <= 'z':
+			custom.Scheduling, err = parseSchedulingInterval(s, part, offset)
+		default:
+			custom.Flexible, err = parseFlexibleInterval(s, part, offset)
+		}
+		if err != nil {
+			return
+		}
+		if part != "" {
+			d.Custom = append(d.Custom, custom)
+		}
+		offset += len(part) + 1
+	}
+	return
+}
+
+func parseFlexibleInterval(input, s string, offset int) (*FlexibleInterval, error) {
+	slash := strings.IndexByte(s, '/')
+	if slash < 0 {
+		return nil, &TimeSyntaxError{input, s, offset, "expected an interval and a time period separated by a slash"}
+	}
+	interval, err := parseTimeUnit(input, s[:slash], offset)
+	if err != nil {
+		return nil, err
+	}
+	period, err := parseWeekPeriod(input, s[slash+1:], offset+slash+1)
+	if err != nil {
+		return nil, err
+	}
+	return &FlexibleInterval{interval, period}, nil
+}
+
+func (d Delay) String() string {
+	parts := []string{d.Interval.String()}
+	for _, c := range d.Custom {
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, ";")
+}
+
+func (c CustomInterval) String() string {
+	switch {
+	case c.Flexible != nil:
+		return c.Flexible.String()
+	case c.Scheduling != nil:
+		return c.Scheduling.String()
+	}
+	return c.Macro
+}
+
+func (f FlexibleInterval) String() string {
+	return f.Interval.String() + "/" + f.Period.String()
+}
+
+// SchedulingInterval is a scheduling interval, "md1-15wd1h9m0-30/10":
+// filters on month days, week days, hours, minutes and seconds, in that order.
+type SchedulingInterval struct {
+	MonthDays []ScheduleRange
+	WeekDays  []ScheduleRange
+	Hours     []ScheduleRange
+	Minutes   []ScheduleRange
+	Seconds   []ScheduleRange
+}
+
+// ScheduleRange is a scheduling filter element, "<from>[-<to>][/<step>]" or "/<step>"
+type ScheduleRange struct {
+	From int
+	To   int
+	Step int  // 0 when not specified
+	All  bool // the range is omitted and covers all the values of the filter, as in "/10"
+}
+
+type scheduleFilter struct {
+	prefix string
+	lo, hi int
+	field  func(*SchedulingInterval) *[]ScheduleRange
+}
+
+var scheduleFilters = []scheduleFilter{
+	{"md", 1, 31, func(s *SchedulingInterval) *[]ScheduleRange { return &s.MonthDays }},
+	{"wd", 1, 7, func(s *SchedulingInterval) *[]ScheduleRange { return &s.WeekDays }},
+	{"h", 0, 23, func(s *SchedulingInterval) *[]ScheduleRange { return &s.Hours }},
+	{"m", 0, 59, func(s *SchedulingInterval) *[]ScheduleRange { return &s.Minutes }},
+	{"s", 0, 59, func(s *SchedulingInterval) *[]ScheduleRange { return &s.Seconds }},
+}
+
+// ParseSchedulingInterval Parses a scheduling interval such as "md1wd1h9" or "m/10".
+func ParseSchedulingInterval(s string) (*SchedulingInterval, error) {
+	return parseSchedulingInterval(s, s, 0)
+}
+
+func parseSchedulingInterval(input, s string, offset int) (*SchedulingInterval, error) {
+	res := &SchedulingInterval{}
+	next := 0
+	for i := 0; i < len(s); {
+		f := -1
+		for j := next; j < len(scheduleFilters); j++ {
+			if strings.HasPrefix(s[i:], scheduleFilters[j].prefix) {
+				f = j
+				break
+			}
+		}
+		start, end := i, i
+		if f >= 0 {
+			start = i + len(scheduleFilters[f].prefix)
+			end = start
+			for end < len(s) && strings.IndexByte("0123456789,-/", s[end]) >= 0 {
+				end++
+			}
+		}
+		if start == end {
+			return nil, &TimeSyntaxError{input, s[i:], offset + i, "expected md, wd, h, m or s filters with values, in that order"}
+		}
+		filter := scheduleFilters[f]
+
+		ranges, err := parseScheduleRanges(input, s[start:end], offset+start, filter.lo, filter.hi)
+		if err != nil {
+			return nil, err
+		}
+		*filter.field(res) = ranges
+		next, i = f+1, end
+	}
+	if s == "" {
+		return nil, &TimeSyntaxError{input, s, offset, "expected at least one filter"}
+	}
+	return res, nil
+}
+
+func parseScheduleRanges(input, s string, offset, lo, hi int) (ranges []ScheduleRange, err error) {
+	for _, part := range strings.Split(s, ",") {
+		var r ScheduleRange
+		bounds := part
+		if slash := strings.IndexByte(part, '/'); slash >= 0 {
+			bounds = part[:slash]
+			r.Step, err = strconv.Atoi(part[slash+1:])
+			if err != nil || r.Step <= 0 || r.Step > hi {
+				return nil, &TimeSyntaxError{input, part, offset, fmt.Sprintf("expected a step between 1 and %d", hi)}
+			}
+			r.All = bounds == ""
+		}
+		if r.All {
+			r.From, r.To = lo, hi
+		} else if r.From, r.To, err = parseRange(input, bounds, offset, lo, hi); err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+		offset += len(part) + 1
+	}
+	return
+}
+
+func (s SchedulingInterval) String() string {
+	var b strings.Builder
+	for _, filter := range scheduleFilters {
+		ranges := *filter.field(&s)
+		if len(ranges) == 0 {
+			continue
+		}
+		b.WriteString(filter.prefix)
+		for i, r := range ranges {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(r.String())
+		}
+	}
+	return b.String()
+}
+
+func (r ScheduleRange) String() string {
+	s := ""
+	if !r.All {
+		s = strconv.Itoa(r.From)
+		if r.To != r.From {
+			s += "-" + strconv.Itoa(r.To)
+		}
+	}
+	if r.Step != 0 {
+		s += "/" + strconv.Itoa(r.Step)
+	}
+	return s
+}
+
+// Timestamp is a point in time exchanged as a unix clock with the Zabbix API.
+// A zero clock, used by Zabbix for "never", is decoded as the zero time.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp Returns the timestamp of t.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{t}
+}
+
+// MarshalJSON encodes t as a unix clock string.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + FormatClock(t.Time) + `"`), nil
+}
+
+// UnmarshalJSON accepts unix clocks as strings, numbers, empty strings and null.
+func (t *Timestamp) UnmarshalJSON(data []byte) (err error) {
+	s, err := scalarString(data)
+	if err == nil {
+		t.Time, err = ParseClock(s)
+	}
+	return
+}
+
+// ParseClock Parses a unix clock, as found in clock fields, "0" or "" being the zero time.
+func ParseClock(s string) (time.Time, error) {
+	return ParseClockNs(s, "")
+}
+
+// ParseClockNs Parses a unix clock and its nanoseconds, as found in clock and ns fields.
+func ParseClockNs(clock, ns string) (t time.Time, err error) {
+	if clock == "" || clock == "0" {
+		return
+	}
+	sec, err := strconv.ParseInt(clock, 10, 64)
+	if err != nil {
+		return t, &TimeSyntaxError{clock, clock, 0, "expected a unix clock"}
+	}
+	var nsec int64
+	if ns != "" {
+		nsec, err = strconv.ParseInt(ns, 10, 64)
+		if err != nil || nsec < 0 || nsec >= int64(time.Second) {
+			return t, &TimeSyntaxError{ns, ns, 0, "expected nanoseconds"}
+		}
+	}
+	return time.Unix(sec, nsec), nil
+}
+
+// FormatClock Formats t as a unix clock, "0" for the zero time.
+func FormatClock(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
@@ -0,0 +1,106 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestTimeUnits(t *testing.T) {
+	cases := map[string]time.Duration{
+		"30":  30 * time.Second,
+		"30s": 30 * time.Second,
+		"5m":  5 * time.Minute,
+		"1h":  time.Hour,
+		"7d":  7 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+	}
+	for s, d := range cases {
+		unit, err := zapi.ParseTimeUnit(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if unit.Duration() != d || unit.String() != s {
+			t.Errorf("Bad time unit for %q: %v, %s", s, unit.Duration(), unit)
+		}
+	}
+
+	unit, err := zapi.ParseTimeUnit("{$HISTORY:\"db\"}")
+	if err != nil || !unit.IsMacro() {
+		t.Errorf("Bad macro time unit: %#v, %v", unit, err)
+	}
+	if _, err = zapi.ParseTimeUnit("1y"); err == nil {
+		t.Error("Expected an error parsing an unknown suffix")
+	}
+	if unit = zapi.NewTimeUnit(90 * time.Minute); unit.String() != "90m" {
+		t.Errorf("Bad time unit for 90 minutes: %s", unit)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	s := "30s;50s/1-5,09:00-18:00;md1-15wd1h9m0-30/10;{$CUSTOM};m/5"
+	delay, err := zapi.ParseDelay(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delay.String() != s {
+		t.Errorf("Delay %q formatted as %q", s, delay.String())
+	}
+	if len(delay.Custom) != 4 {
+		t.Fatalf("Bad custom intervals: %#v", delay.Custom)
+	}
+
+	flexible := delay.Custom[0].Flexible
+	if flexible == nil || flexible.Interval.Duration() != 50*time.Second || flexible.Period.ToDay != 5 || flexible.Period.From != 9*time.Hour {
+		t.Errorf("Bad flexible interval: %#v", flexible)
+	}
+	monday := time.Date(2024, time.July, 15, 10, 0, 0, 0, time.UTC)
+	if !flexible.Period.Contains(monday) || flexible.Period.Contains(monday.AddDate(0, 0, 5)) {
+		t.Errorf("Bad period boundaries: %s", flexible.Period)
+	}
+
+	scheduling := delay.Custom[1].Scheduling
+	if scheduling == nil || len(scheduling.MonthDays) != 1 || scheduling.MonthDays[0].To != 15 || scheduling.Minutes[0].Step != 10 {
+		t.Errorf("Bad scheduling interval: %#v", scheduling)
+	}
+	if delay.Custom[2].Macro != "{$CUSTOM}" || !delay.Custom[3].Scheduling.Minutes[0].All {
+		t.Errorf("Bad custom intervals: %#v", delay.Custom)
+	}
+
+	_, err = zapi.ParseDelay("1m;50s/1-5,09:00-25:00")
+	if e, ok := err.(*zapi.TimeSyntaxError); !ok || e.Segment != "25:00" || e.Offset != 17 {
+		t.Errorf("Expected an error pointing at 25:00, got %v", err)
+	}
+	_, err = zapi.ParseDelay("1m;wd1md2")
+	if e, ok := err.(*zapi.TimeSyntaxError); !ok || e.Segment != "md2" {
+		t.Errorf("Expected an error pointing at md2, got %v", err)
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	var event struct {
+		Clock zapi.Timestamp `json:"clock"`
+		Never zapi.Timestamp `json:"never"`
+	}
+	if err := json.Unmarshal([]byte(`{"clock": "1351090996", "never": "0"}`), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Clock.Unix() != 1351090996 || !event.Never.IsZero() {
+		t.Errorf("Bad timestamps: %#v", event)
+	}
+
+	b, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"clock":"1351090996","never":"0"}` {
+		t.Errorf("Bad marshaled timestamps: %s", b)
+	}
+
+	clock, err := zapi.ParseClockNs("1351090996", "563157632")
+	if err != nil || clock.UnixNano() != 1351090996563157632 {
+		t.Errorf("Bad clock: %v, %v", clock, err)
+	}
+}
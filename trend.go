@@ -0,0 +1,184 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Trend represent Zabbix trend object, the hourly statistics of a numeric item
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/trend/object
+type Trend struct {
+	ItemID   string
+	Clock    time.Time // beginning of the hour
+	Num      int       // number of values received during the hour
+	ValueMin float64
+	ValueAvg float64
+	ValueMax float64
+}
+
+// Trends is an array of Trend
+type Trends []Trend
+
+// trendObject is a trend object as returned by trend.get
+type trendObject struct {
+	ItemID   string      `json:"itemid"`
+	Clock    json.Number `json:"clock"`
+	Num      Int         `json:"num"`
+	ValueMin json.Number `json:"value_min"`
+	ValueAvg json.Number `json:"value_avg"`
+	ValueMax json.Number `json:"value_max"`
+}
+
+// trend converts o to a Trend.
+func (o trendObject) trend() (t Trend, err error) {
+	t.ItemID = o.ItemID
+	t.Num = int(o.Num)
+	if t.Clock, err = ParseClock(o.Clock.String()); err != nil {
+		return
+	}
+	for _, v := range []struct {
+		n json.Number
+		f *float64
+	}{{o.ValueMin, &t.ValueMin}, {o.ValueAvg, &t.ValueAvg}, {o.ValueMax, &t.ValueMax}} {
+		if *v.f, err = v.n.Float64(); err != nil {
+			return t, fmt.Errorf("zabbix: bad trend value %q of item %s: %v", v.n, o.ItemID, err)
+		}
+	}
+	return
+}
+
+// TrendsGet Wrapper for trend.get
+// Gets the trends of the items between from and till, both optional, ordered by time.
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/trend/get
+func (api *API) TrendsGet(itemIDs []string, from, till time.Time) (res Trends, err error) {
+	if err = api.requires(FeatureTrends); err != nil {
+		return
+	}
+	params := Params{
+		"itemids": itemIDs,
+		"output":  "extend",
+	}
+	if !from.IsZero() {
+		params["time_from"] = from.Unix()
+	}
+	if !till.IsZero() {
+		params["time_till"] = till.Unix()
+	}
+
+	var objects []trendObject
+	if err = api.CallWithErrorParse("trend.get", params, &objects); err != nil {
+		return
+	}
+	res = make(Trends, len(objects))
+	for i, o := range objects {
+		if res[i], err = o.trend(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Clock.Before(res[j].Clock)
+	})
+	return
+}
+
+// Sample is a point of an item series, either a history value or an hourly trend
+type Sample struct {
+	Clock time.Time
+	Num   int // 1 for history values
+	Min   float64
+	Avg   float64
+	Max   float64
+	Trend bool // the sample comes from trends
+}
+
+// Samples is an array of Sample
+type Samples []Sample
+
+// ItemSeries Gets the values of a numeric item between from and till as a single series,
+// using history within the history storage period of the item and hourly trends beyond it.
+// The item must come from ItemsGet, with at least its itemid, value_type and history properties.
+// When the storage period is a macro, trends are used before the earliest history value.
+func (api *API) ItemSeries(item Item, from, till time.Time) (res Samples, err error) {
+	if item.ValueType != Float && item.ValueType != Unsigned {
+		return nil, fmt.Errorf("zabbix: item %s of value type %s has no trends", item.ItemID, item.ValueType)
+	}
+	if till.IsZero() {
+		till = time.Now()
+	}
+
+	// cutoff is the beginning of the history, found from the storage period when possible
+	cutoff := from
+	known := false
+	if api.Supports(FeatureTrends) {
+		var storage TimeUnit
+		if storage, err = ParseTimeUnit(item.History); err != nil {
+			return
+		}
+		if !storage.IsMacro() {
+			period := storage.Duration()
+			if storage.Suffix == 0 && api.Supports(FeatureLegacyItemFields) {
+				// before 3.4 the storage period is a number of days
+				period = time.Duration(storage.Value) * 24 * time.Hour
+			}
+			if c := time.Now().Add(-period); c.After(cutoff) {
+				cutoff = c
+			}
+			known = true
+		}
+	}
+
+	history, err := api.HistoryGet([]string{item.ItemID}, cutoff, till)
+	if err != nil {
+		return
+	}
+	if !api.Supports(FeatureTrends) {
+		return historySamples(history, from), nil
+	}
+	if !known {
+		// unknown storage period, history begins with its earliest value
+		cutoff = till
+		if len(history) > 0 {
+			cutoff = history[0].Clock
+		}
+	}
+
+	// trends cover whole hours, history goes on from the end of the hour the cutoff lies in
+	boundary := from
+	if cutoff.After(from) {
+		boundary = cutoff.Truncate(time.Hour)
+		if boundary.Before(cutoff) {
+			boundary = boundary.Add(time.Hour)
+		}
+	}
+	if boundary.After(till) {
+		boundary = till
+	}
+	if boundary.After(from) {
+		var trends Trends
+		if trends, err = api.TrendsGet([]string{item.ItemID}, from, boundary.Add(-time.Second)); err != nil {
+			return
+		}
+		for _, t := range trends {
+			res = append(res, Sample{Clock: t.Clock, Num: t.Num, Min: t.ValueMin, Avg: t.ValueAvg, Max: t.ValueMax, Trend: true})
+		}
+	}
+	return append(res, historySamples(history, boundary)...), nil
+}
+
+// historySamples converts the numeric history records from the given time onward to samples.
+func historySamples(history HistoryRecords, from time.Time) (res Samples) {
+	for _, r := range history {
+		if r.Clock.Before(from) {
+			continue
+		}
+		v := r.Float
+		if r.ValueType == Unsigned {
+			v = float64(r.Unsigned)
+		}
+		res = append(res, Sample{Clock: r.Clock, Num: 1, Min: v, Avg: v, Max: v})
+	}
+	return
+}
@@ -0,0 +1,140 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestTrends(t *testing.T) {
+	skipTestIfVersionLessThan(t, "4.0", "introduced trend.get")
+
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	item := testCreateItem(host, t)
+	defer testDeleteItem(item, t)
+
+	trends, err := api.TrendsGet([]string{item.ItemID}, time.Now().Add(-24*time.Hour), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trends) != 0 {
+		t.Errorf("Expected no trends for a new item, got %d", len(trends))
+	}
+
+	created, err := api.ItemGetByID(item.ItemID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples, err := api.ItemSeries(*created, time.Now().AddDate(0, -1, 0), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 0 {
+		t.Errorf("Expected an empty series for a new item, got %d samples", len(samples))
+	}
+}
+
+// testFakeSeries returns a fake server holding the float item 1,
+// with a history value every 10 minutes over the last retention and hourly trends over the last 3 days.
+func testFakeSeries(t *testing.T, now time.Time, retention time.Duration) *zapi.API {
+	return testFakeAPI(t, "6.0.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		var p struct {
+			TimeFrom int64 `json:"time_from"`
+			TimeTill int64 `json:"time_till"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return "", &zapi.Error{Code: -32602, Message: err.Error()}
+		}
+		var objects []string
+		switch method {
+		case "item.get":
+			return `[{"itemid":"1","value_type":"0"}]`, nil
+		case "history.get":
+			first := now.Add(-retention).Truncate(10 * time.Minute).Add(10 * time.Minute)
+			for c := first; !c.After(now); c = c.Add(10 * time.Minute) {
+				if c.Unix() >= p.TimeFrom && c.Unix() <= p.TimeTill {
+					objects = append(objects, fmt.Sprintf(`{"itemid":"1","clock":"%d","ns":"0","value":"1"}`, c.Unix()))
+				}
+			}
+		case "trend.get":
+			for c := now.Add(-72 * time.Hour).Truncate(time.Hour); !c.After(now); c = c.Add(time.Hour) {
+				if c.Unix() >= p.TimeFrom && c.Unix() <= p.TimeTill {
+					objects = append(objects, fmt.Sprintf(`{"itemid":"1","clock":"%d","num":"6","value_min":"1","value_avg":"1","value_max":"1"}`, c.Unix()))
+				}
+			}
+		}
+		return "[" + strings.Join(objects, ",") + "]", nil
+	})
+}
+
+func TestItemSeriesBoundary(t *testing.T) {
+	now := time.Now()
+	from := now.Add(-48 * time.Hour)
+	cases := []struct {
+		history string
+		cutoff  time.Time // oldest history value used
+	}{
+		{"1d", now.Add(-24 * time.Hour)},
+		{"{$HISTORY}", now.Add(-30 * time.Hour).Truncate(10 * time.Minute).Add(10 * time.Minute)},
+	}
+	for _, c := range cases {
+		api := testFakeSeries(t, now, 30*time.Hour)
+		item := zapi.Item{ItemID: "1", ValueType: zapi.Float, History: c.history}
+		samples, err := api.ItemSeries(item, from, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		trends, history := 0, 0
+		for i, s := range samples {
+			if s.Trend {
+				trends++
+			} else {
+				history++
+			}
+			if i == 0 {
+				continue
+			}
+			prev := samples[i-1]
+			switch {
+			case !s.Clock.After(prev.Clock):
+				t.Errorf("History %s: sample %d at %s is not after the previous one at %s", c.history, i, s.Clock, prev.Clock)
+			case prev.Trend && s.Trend && s.Clock.Sub(prev.Clock) != time.Hour:
+				t.Errorf("History %s: gap between the trends at %s and %s", c.history, prev.Clock, s.Clock)
+			case prev.Trend && !s.Trend && (s.Clock.Before(prev.Clock.Add(time.Hour)) || s.Clock.Sub(prev.Clock) > time.Hour+10*time.Minute):
+				t.Errorf("History %s: bad boundary between the trend at %s and the history at %s", c.history, prev.Clock, s.Clock)
+			case !prev.Trend && s.Trend:
+				t.Errorf("History %s: trend at %s after history", c.history, s.Clock)
+			case !prev.Trend && s.Clock.Sub(prev.Clock) != 10*time.Minute:
+				t.Errorf("History %s: gap between the history at %s and %s", c.history, prev.Clock, s.Clock)
+			}
+		}
+
+		// trends cover the whole hours from from to the hour following the cutoff, history the rest
+		first, boundary := from.Truncate(time.Hour), c.cutoff.Truncate(time.Hour)
+		if first.Before(from) {
+			first = first.Add(time.Hour)
+		}
+		if boundary.Before(c.cutoff) {
+			boundary = boundary.Add(time.Hour)
+		}
+		if expected := int(boundary.Sub(first) / time.Hour); trends != expected {
+			t.Errorf("History %s: expected %d trends, got %d", c.history, expected, trends)
+		}
+		if history == 0 || samples[len(samples)-1].Clock.Before(now.Add(-10*time.Minute)) {
+			t.Errorf("History %s: the series does not reach the last history value", c.history)
+		}
+	}
+}
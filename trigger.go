@@ -39,6 +39,49 @@ const (
 	Problem ValueType = 1
 )
 
+// MarshalJSON encodes t as a string.
+func (t SeverityType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *SeverityType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var severityTypeNames = enumNames{
+	kind: "severity",
+	names: map[int][]string{
+		int(NotClassified): {"Not classified"},
+		int(Information):   {"Information"},
+		int(Warning):       {"Warning"},
+		int(Average):       {"Average"},
+		int(High):          {"High"},
+		int(Critical):      {"Disaster", "Critical"},
+	},
+}
+
+func (t SeverityType) String() string {
+	return severityTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t SeverityType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *SeverityType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseSeverityType(string(text))
+	return
+}
+
+// ParseSeverityType Parses a severity from its display name or number.
+func ParseSeverityType(s string) (SeverityType, error) {
+	v, err := severityTypeNames.parse(s)
+	return SeverityType(v), err
+}
+
 // TriggerFunction The function objects represents the functions used in the trigger expression
 type TriggerFunction struct {
 	FunctionID string `json:"functionid"`
@@ -60,8 +103,8 @@ type Trigger struct {
 	//TemplateId  string    `json:"templateid"`
 	//Value ValueType `json:""`
 
-	Priority     SeverityType     `json:"priority,string"`
-	Status       StatusType       `json:"status,string"`
+	Priority     SeverityType     `json:"priority"`
+	Status       StatusType       `json:"status"`
 	Dependencies TriggerIDs       `json:"dependencies,omitempty"`
 	Functions    TriggerFunctions `json:"functions,omitempty"`
 	// Items contained by the trigger in the items property.
@@ -113,10 +156,8 @@ func (api *API) TriggersCreate(triggers Triggers) (err error) {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	triggerids := result["triggerids"].([]interface{})
-	for i, id := range triggerids {
-		triggers[i].TriggerID = id.(string)
+	for i, id := range resultIDs(response.Result, "triggerids") {
+		triggers[i].TriggerID = id
 	}
 	return
 }
@@ -7,16 +7,16 @@ type TriggerPrototype struct {
 	Description        string              `json:"description"`         // Required
 	Expression         string              `json:"expression"`          // Required
 	Commemts           string              `json:"comments,omitempty"`
-	Priority           SeverityType        `json:"priority,omitempty,string"`
-	Status             StatusType          `json:"status,omitempty,string"`
+	Priority           SeverityType        `json:"priority,omitempty"`
+	Status             StatusType          `json:"status,omitempty"`
 	TemplateID         string              `json:"templateid,omitempty"` // Readonly
-	Type               int                 `json:"type,omitempty,string"`
+	Type               Int                 `json:"type,omitempty"`
 	URL                string              `json:"url,omitempty"`
-	RecoveryMode       int                 `json:"recovery_mode,omitempty,string"`
+	RecoveryMode       Int                 `json:"recovery_mode,omitempty"`
 	RecoveryExpression string              `json:"recovery_expression,omitempty"`
-	CorrelationMode    int                 `json:"correlation_mode,omitempty,string"`
+	CorrelationMode    Int                 `json:"correlation_mode,omitempty"`
 	CorrelationTag     string              `json:"correlation_tag,omitempty"`
-	ManualClose        int                 `json:"manual_close,omitempty,string"`
+	ManualClose        Int                 `json:"manual_close,omitempty"`
 	Dependencies       TriggerPrototypeIDs `json:"dependencies,omitempty"`
 
 	Functions TriggerFunctions `json:"functions,omitempty"`
@@ -67,10 +67,8 @@ func (api *API) TriggerPrototypesCreate(triggers TriggerPrototypes) (err error)
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	triggerids := result["triggerids"].([]interface{})
-	for i, id := range triggerids {
-		triggers[i].TriggerID = id.(string)
+	for i, id := range resultIDs(response.Result, "triggerids") {
+		triggers[i].TriggerID = id
 	}
 	return
 }
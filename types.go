@@ -0,0 +1,248 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// unmarshalFlexible decodes data into v like json.Unmarshal, string properties accepting JSON numbers too,
+// as Zabbix versions and API proxies do not agree on the type of IDs.
+// Types with their own UnmarshalJSON decode their properties with it in turn.
+func unmarshalFlexible(data []byte, v interface{}) error {
+	var generic interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&generic); err != nil {
+		return err
+	}
+	if generic, changed := stringifyNumbers(generic, reflect.TypeOf(v)); changed {
+		b, err := json.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		data = b
+	}
+	return json.Unmarshal(data, v)
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// stringifyNumbers converts the numbers of a generic JSON value to strings where t expects strings,
+// telling if it changed anything.
+func stringifyNumbers(value interface{}, t reflect.Type) (interface{}, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return value, false
+	}
+
+	changed := false
+	convert := func(v interface{}, t reflect.Type) interface{} {
+		v, c := stringifyNumbers(v, t)
+		changed = changed || c
+		return v
+	}
+	switch t.Kind() {
+	case reflect.String:
+		if n, ok := value.(json.Number); ok {
+			return n.String(), true
+		}
+	case reflect.Slice, reflect.Array:
+		if values, ok := value.([]interface{}); ok {
+			for i, v := range values {
+				values[i] = convert(v, t.Elem())
+			}
+		}
+	case reflect.Map:
+		if object, ok := value.(map[string]interface{}); ok {
+			for key, v := range object {
+				object[key] = convert(v, t.Elem())
+			}
+		}
+	case reflect.Struct:
+		if object, ok := value.(map[string]interface{}); ok {
+			fields := jsonFields(t)
+			for key, v := range object {
+				if ft, ok := fields[strings.ToLower(key)]; ok {
+					object[key] = convert(v, ft)
+				}
+			}
+		}
+	}
+	return value, changed
+}
+
+// jsonFieldsCache holds the result of jsonFields by struct type.
+var jsonFieldsCache sync.Map
+
+// jsonFields returns the types of the properties of a struct type by lowercase JSON name,
+// including those of embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	if fields, ok := jsonFieldsCache.Load(t); ok {
+		return fields.(map[string]reflect.Type)
+	}
+
+	fields := make(map[string]reflect.Type)
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	// properties of embedded structs are hidden by those of the struct
+	for _, e := range embedded {
+		for name, ft := range jsonFields(e) {
+			if _, present := fields[name]; !present {
+				fields[name] = ft
+			}
+		}
+	}
+
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
+
+// Int is an integer property, sent as a string to the Zabbix API.
+// It is decoded from JSON strings, numbers, empty strings and null alike.
+type Int int
+
+// MarshalJSON encodes i as a string.
+func (i Int) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(i))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (i *Int) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(i))
+}
+
+// Number is a decimal property, sent as a string to the Zabbix API.
+// It is decoded from JSON strings, numbers, empty strings and null alike.
+type Number float64
+
+// MarshalJSON encodes n as a string.
+func (n Number) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + strconv.FormatFloat(float64(n), 'f', -1, 64) + `"`), nil
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (n *Number) UnmarshalJSON(data []byte) error {
+	s, err := scalarString(data)
+	if err != nil {
+		return err
+	}
+	if s == "" {
+		*n = 0
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("zabbix: expected a number, got %s", data)
+	}
+	*n = Number(v)
+	return nil
+}
+
+// scalarString returns the text of a JSON string or number, or an empty string for null.
+func scalarString(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return "", nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		err := json.Unmarshal(data, &s)
+		return s, err
+	case len(data) > 0 && (data[0] == '-' || (data[0] >= '0' && data[0] <= '9')):
+		return string(data), nil
+	}
+	return "", fmt.Errorf("zabbix: expected a string or a number, got %s", data)
+}
+
+// marshalEnum encodes an enumeration or integer value as a string, as expected by the Zabbix API.
+func marshalEnum(v int) ([]byte, error) {
+	return []byte(`"` + strconv.Itoa(v) + `"`), nil
+}
+
+// unmarshalEnum decodes an enumeration or integer value from a JSON string, number, empty string or null.
+func unmarshalEnum(data []byte, v *int) error {
+	s, err := scalarString(data)
+	if err != nil {
+		return err
+	}
+	if s == "" {
+		*v = 0
+		return nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("zabbix: expected an integer, got %s", data)
+	}
+	*v = i
+	return nil
+}
+
+// resultIDs returns the IDs listed under key in the result of a create, update or delete call.
+// Zabbix returns them as an array or as an object, of strings or numbers depending on the version.
+// Objects are keyed by the index of the input objects, their IDs are returned in that order.
+func resultIDs(result interface{}, key string) (ids []string) {
+	object, _ := result.(map[string]interface{})
+	var values []interface{}
+	switch v := object[key].(type) {
+	case []interface{}:
+		values = v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			a, errA := strconv.Atoi(keys[i])
+			b, errB := strconv.Atoi(keys[j])
+			if errA != nil || errB != nil {
+				return keys[i] < keys[j]
+			}
+			return a < b
+		})
+		for _, k := range keys {
+			values = append(values, v[k])
+		}
+	}
+
+	for _, value := range values {
+		switch id := value.(type) {
+		case string:
+			ids = append(ids, id)
+		case float64:
+			ids = append(ids, strconv.FormatFloat(id, 'f', -1, 64))
+		}
+	}
+	return
+}
@@ -0,0 +1,102 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func TestFlexibleDecoding(t *testing.T) {
+	var hosts zapi.Hosts
+	data := `[
+		{"hostid": "10084", "host": "a", "available": "1", "status": "0"},
+		{"hostid": 10085, "host": "b", "available": 2, "status": ""},
+		{"hostid": null, "host": "c", "available": null, "status": 1}
+	]`
+	if err := json.Unmarshal([]byte(data), &hosts); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		id        string
+		available zapi.AvailableType
+		status    zapi.StatusType
+	}{
+		{"10084", zapi.Available, zapi.Monitored},
+		{"10085", zapi.Unavailable, zapi.Monitored},
+		{"", zapi.Unknown, zapi.Unmonitored},
+	}
+	for i, e := range expected {
+		h := hosts[i]
+		if h.HostID != e.id || h.Available != e.available || h.Status != e.status {
+			t.Errorf("Bad host %d: %#v", i, h)
+		}
+	}
+
+	b, err := json.Marshal(zapi.HostInterface{Type: zapi.SNMP, Main: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wire map[string]interface{}
+	if err = json.Unmarshal(b, &wire); err != nil {
+		t.Fatal(err)
+	}
+	if wire["type"] != "2" || wire["main"] != "1" {
+		t.Errorf("Enums should be sent as strings: %s", b)
+	}
+
+	var graphs zapi.Graphs
+	if err = json.Unmarshal([]byte(`[{"yaxismax": "100.5"}, {"yaxismax": 100.5}, {"yaxismax": ""}, {"yaxismax": null}]`), &graphs); err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []zapi.Number{100.5, 100.5, 0, 0} {
+		if graphs[i].YMax != expected {
+			t.Errorf("Bad graph %d axis maximum %v instead of %v", i, graphs[i].YMax, expected)
+		}
+	}
+	if b, err = json.Marshal(zapi.Number(-0.25)); err != nil || string(b) != `"-0.25"` {
+		t.Errorf("Decimals should be sent as strings: %s, %v", b, err)
+	}
+
+	var item zapi.Item
+	if err = json.Unmarshal([]byte(`{"value_type": "three"}`), &item); err == nil {
+		t.Errorf("Expected an error decoding a bad value type, got %#v", item)
+	}
+}
+
+func TestFlexibleResults(t *testing.T) {
+	api := testFakeAPI(t, "6.0.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		return `[{"itemid": 23, "hostid": null, "interfaceid": "", "name": 42, "value_type": 3,
+			"hosts": [{"hostid": 10084, "interfaces": [{"interfaceid": 1, "port": 10050, "details": []}]}]}]`, nil
+	})
+	items, err := api.ItemsGet(zapi.Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].ItemID != "23" || items[0].HostID != "" || items[0].Name != "42" || items[0].ValueType != zapi.Unsigned {
+		t.Fatalf("Bad items: %#v", items)
+	}
+	if hosts := items[0].ItemParent; len(hosts) != 1 || hosts[0].HostID != "10084" || hosts[0].Interfaces[0].InterfaceID != "1" || hosts[0].Interfaces[0].Port != "10050" {
+		t.Errorf("Bad item hosts: %#v", hosts)
+	}
+}
+
+func TestResultIDsOrder(t *testing.T) {
+	// some versions return the created IDs as an object keyed by the index of the input objects
+	api := testFakeAPI(t, "3.0.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		return `{"groupids":{"10":"110","2":"102","0":"100","9":"109","1":"101","3":"103",
+			"8":"108","4":"104","7":"107","5":"105","6":"106"}}`, nil
+	})
+	groups := make(zapi.HostGroups, 11)
+	if err := api.HostGroupsCreate(groups); err != nil {
+		t.Fatal(err)
+	}
+	for i, group := range groups {
+		if id := fmt.Sprint(100 + i); group.GroupID != id {
+			t.Errorf("Group %d has ID %q instead of %q", i, group.GroupID, id)
+		}
+	}
+}
@@ -1,32 +1,196 @@
 package zabbix
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type (
-	// Whether to pause escalation during maintenance periods or not.
-	// "debug_mode" in https://www.zabbix.com/documentation/4.0/manual/api/reference/user/object#user_group
+	// UserType Type of the user, replaced by the type of its role from 5.2
+	// "type" in https://www.zabbix.com/documentation/4.0/manual/api/reference/user/object
 	UserType int
 )
 
+// NOTE: the user types are the values of the API, 1 to 3; they were wrongly numbered 0 to 2 before.
 const (
-	ZabbixUser       UserType = 0
-	ZabbixAdmin      UserType = 1
-	ZabbixSuperAdmin UserType = 2
+	// ZabbixUser (default) user
+	ZabbixUser UserType = 1
+	// ZabbixAdmin admin
+	ZabbixAdmin UserType = 2
+	// ZabbixSuperAdmin super admin
+	ZabbixSuperAdmin UserType = 3
 )
 
-// User represent Zabbix user group object
-// https://www.zabbix.com/documentation/4.0/manual/api/reference/user/object
+// MarshalJSON encodes t as a string.
+func (t UserType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *UserType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var userTypeNames = enumNames{
+	kind: "user type",
+	names: map[int][]string{
+		int(ZabbixUser):       {"Zabbix User", "User"},
+		int(ZabbixAdmin):      {"Zabbix Admin", "Admin"},
+		int(ZabbixSuperAdmin): {"Zabbix Super Admin", "Super admin"},
+	},
+}
+
+func (t UserType) String() string {
+	return userTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t UserType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *UserType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseUserType(string(text))
+	return
+}
+
+// ParseUserType Parses a user type from its display name or number.
+func ParseUserType(s string) (UserType, error) {
+	v, err := userTypeNames.parse(s)
+	return UserType(v), err
+}
+
+// UserRole represent Zabbix role object, as returned with selectRole
+// https://www.zabbix.com/documentation/5.2/manual/api/reference/role/object
+type UserRole struct {
+	RoleID   string   `json:"roleid"`
+	Name     string   `json:"name"`
+	Type     UserType `json:"type"`
+	ReadOnly Int      `json:"readonly"`
+}
+
+// User represent Zabbix user object
+// https://www.zabbix.com/documentation/5.2/manual/api/reference/user/object
 type User struct {
-	UserID   string   `json:"userid,omitempty"`
-	Alias    string   `json:"alias"`
-	Username string   `json:"username"` // Renamed field alias → username in user object from 5.4↑
-	Name     string   `json:"name,omitempty"`
-	Surname  string   `json:"surname,omitempty"`
-	Type     UserType `json:"type,string,omitempty"`
-	Url      string   `json:"url,omitempty"`
+	UserID   string `json:"userid,omitempty"`
+	Username string `json:"username"` // Sent as "alias" before Zabbix 5.4
+	Alias    string `json:"-"`        // Deprecated: mirrors Username, only sent when Username is empty
+	Name     string `json:"name,omitempty"`
+	Surname  string `json:"surname,omitempty"`
+	Url      string `json:"url,omitempty"`
+
+	// Type of the user before 5.2. From 5.2 it is sent as the matching default role when RoleID is empty,
+	// and decoded from the role returned with selectRole.
+	Type UserType `json:"type,omitempty"`
+	// NOTE: new in 5.2, sent as the matching type before when it is a default role, unsupported otherwise
+	RoleID string `json:"roleid,omitempty"`
+
+	// Password is only sent, CurrentPassword being required from 6.4 when users change their own password.
+	Password        string `json:"passwd,omitempty"`
+	CurrentPassword string `json:"current_passwd,omitempty"`
+
+	AutoLogin   Int    `json:"autologin,omitempty"`
+	AutoLogout  string `json:"autologout,omitempty"` // such as "15m", "0" to disable
+	Lang        string `json:"lang,omitempty"`       // such as "en_US", "default" for the system default
+	Theme       string `json:"theme,omitempty"`
+	Refresh     string `json:"refresh,omitempty"` // such as "30s"
+	RowsPerPage Int    `json:"rows_per_page,omitempty"`
+	// NOTE: new in 5.2
+	Timezone string `json:"timezone,omitempty"`
+
+	// Readonly login attempts
+	AttemptFailed Int        `json:"attempt_failed,omitempty"`
+	AttemptIP     string     `json:"attempt_ip,omitempty"`
+	AttemptClock  *Timestamp `json:"attempt_clock,omitempty"`
+
+	// Groups of the user, required on creation, returned with selectUsrgrps.
+	UserGroups UserGroupIDs `json:"usrgrps,omitempty"`
+
+	// Medias of the user, returned with selectMedias.
+	// NOTE: sent as user_medias before Zabbix 5.2
+	Medias UserMedias `json:"medias,omitempty"`
+
+	// Role is returned with selectRole.
+	Role *UserRole `json:"role,omitempty"`
 }
 
 // Users is an array of User
 type Users []User
 
+// userFields adapts User fields to the server version
+var userFields = fieldRules{
+	{Field: "username", Feature: FeatureUsername, Legacy: "alias"},
+	{Field: "medias", Feature: FeatureUserMedias, Legacy: "user_medias"},
+	{Field: "timezone", Feature: FeatureUserRoles},
+	{Field: "current_passwd", Feature: FeatureUserProvisioning},
+}
+
+// MarshalJSON falls back to the deprecated Alias when Username is empty, and omits read-only fields.
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User
+	if u.Username == "" {
+		u.Username = u.Alias
+	}
+	u.AttemptFailed, u.AttemptIP, u.AttemptClock = 0, "", nil
+	u.Role = nil
+	return json.Marshal(user(u))
+}
+
+// UnmarshalJSON fills Username from either "username" or "alias", and Type from the role when selected.
+func (u *User) UnmarshalJSON(data []byte) error {
+	type user User
+	data, err := userFields.canonical(data)
+	if err != nil {
+		return err
+	}
+	if err = unmarshalFlexible(data, (*user)(u)); err != nil {
+		return err
+	}
+	u.Alias = u.Username
+	if u.Role != nil && u.Type == 0 {
+		u.Type = u.Role.Type
+	}
+	return nil
+}
+
+// userParams returns the parameters of user.create and user.update for the server version.
+// The default roles of 5.2 match the user types, a type without role is sent as its role and the other way round.
+// Other roles are unsupported before 5.2.
+func (api *API) userParams(users Users) (params interface{}, err error) {
+	if params, err = api.marshalFor(users, userFields); err != nil {
+		return
+	}
+	for _, o := range params.([]interface{}) {
+		object := o.(map[string]interface{})
+		if api.ServerVersion == nil {
+			continue
+		}
+		from, to := "type", "roleid"
+		if api.legacy(FeatureUserRoles) {
+			if role, present := object["roleid"]; present && !defaultRole(role) {
+				// only the default roles match a user type
+				return nil, api.requires(FeatureUserRoles)
+			}
+			from, to = to, from
+		}
+		if _, present := object[to]; !present && object[from] != nil {
+			object[to] = object[from]
+		}
+		delete(object, from)
+	}
+	return
+}
+
+// defaultRole tells if a marshalled role ID is one of the default roles, whose IDs are the matching user types.
+func defaultRole(role interface{}) bool {
+	switch fmt.Sprint(role) {
+	case "1", "2", "3":
+		return true
+	}
+	return false
+}
+
 // UsersGet Wrapper for user.get
 // https://www.zabbix.com/documentation/4.0/manual/api/reference/user/get
 func (api *API) UsersGet(params Params) (res Users, err error) {
@@ -36,3 +200,134 @@ func (api *API) UsersGet(params Params) (res Users, err error) {
 	err = api.CallWithErrorParse("user.get", params, &res)
 	return
 }
+
+// UserGetByID Gets user by Id only if there is exactly 1 matching user.
+// Its groups, medias and, from 5.2, role are selected.
+func (api *API) UserGetByID(id string) (res *User, err error) {
+	return api.userGet(Params{"userids": id})
+}
+
+// UserGetByUsername Gets user by username only if there is exactly 1 matching user.
+// Its groups, medias and, from 5.2, role are selected.
+func (api *API) UserGetByUsername(username string) (res *User, err error) {
+	key := "alias"
+	if api.Supports(FeatureUsername) {
+		key = "username"
+	}
+	return api.userGet(Params{"filter": map[string]string{key: username}})
+}
+
+// userGet gets the only user matching params, with its groups, medias and role.
+func (api *API) userGet(params Params) (res *User, err error) {
+	params["selectUsrgrps"] = []string{"usrgrpid"}
+	params["selectMedias"] = "extend"
+	if api.Supports(FeatureUserRoles) {
+		params["selectRole"] = "extend"
+	}
+	users, err := api.UsersGet(params)
+	if err != nil {
+		return
+	}
+
+	if len(users) == 1 {
+		res = &users[0]
+	} else {
+		e := ExpectedOneResult(len(users))
+		err = &e
+	}
+	return
+}
+
+// UsersCreate Wrapper for user.create
+// https://www.zabbix.com/documentation/5.2/manual/api/reference/user/create
+func (api *API) UsersCreate(users Users) (err error) {
+	params, err := api.userParams(users)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("user.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "userids") {
+		users[i].UserID = id
+	}
+	return
+}
+
+// UsersUpdate Wrapper for user.update
+// https://www.zabbix.com/documentation/5.2/manual/api/reference/user/update
+func (api *API) UsersUpdate(users Users) (err error) {
+	params, err := api.userParams(users)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("user.update", params)
+	return
+}
+
+// UsersDelete Wrapper for user.delete
+// Cleans UserID in all users elements if call succeed.
+// https://www.zabbix.com/documentation/5.2/manual/api/reference/user/delete
+func (api *API) UsersDelete(users Users) (err error) {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.UserID
+	}
+
+	err = api.UsersDeleteByIds(ids)
+	if err == nil {
+		for i := range users {
+			users[i].UserID = ""
+		}
+	}
+	return
+}
+
+// UsersDeleteByIds Wrapper for user.delete
+// https://www.zabbix.com/documentation/5.2/manual/api/reference/user/delete
+func (api *API) UsersDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("user.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "userids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// UsersUnblock Wrapper for user.unblock
+// Unblocks users blocked after too many failed login attempts.
+// https://www.zabbix.com/documentation/5.2/manual/api/reference/user/unblock
+func (api *API) UsersUnblock(ids []string) (err error) {
+	response, err := api.CallWithError("user.unblock", ids)
+	if err != nil {
+		return
+	}
+
+	if unblocked := resultIDs(response.Result, "userids"); len(ids) != len(unblocked) {
+		err = &ExpectedMore{len(ids), len(unblocked)}
+	}
+	return
+}
+
+// UsersProvision Wrapper for user.provision
+// Updates users created from an LDAP or SAML user directory with the data of the directory.
+// https://www.zabbix.com/documentation/6.4/manual/api/reference/user/provision
+func (api *API) UsersProvision(ids []string) (err error) {
+	if err = api.requires(FeatureUserProvisioning); err != nil {
+		return
+	}
+	response, err := api.CallWithError("user.provision", ids)
+	if err != nil {
+		return
+	}
+
+	if provisioned := resultIDs(response.Result, "userids"); len(ids) != len(provisioned) {
+		err = &ExpectedMore{len(ids), len(provisioned)}
+	}
+	return
+}
@@ -1,9 +1,28 @@
 package zabbix
 
 type (
+	// GUIAccess Frontend authentication method of the users of a group
+	// "gui_access" in https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/object#user_group
+	GUIAccess int
+
 	// Whether to pause escalation during maintenance periods or not.
 	// "debug_mode" in https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/object#user_group
 	DebugModeType int
+
+	// PermissionType access level to hosts or templates
+	// "permission" in https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/object#permission
+	PermissionType int
+)
+
+const (
+	// GUIAccessDefault (default) system default authentication
+	GUIAccessDefault GUIAccess = 0
+	// GUIAccessInternal internal authentication
+	GUIAccessInternal GUIAccess = 1
+	// GUIAccessLDAP LDAP authentication
+	GUIAccessLDAP GUIAccess = 2
+	// GUIAccessDisabled frontend access disabled
+	GUIAccessDisabled GUIAccess = 3
 )
 
 const (
@@ -11,19 +30,244 @@ const (
 	DebugModeEnabled  DebugModeType = 1
 )
 
+const (
+	// PermissionDeny access denied
+	PermissionDeny PermissionType = 0
+	// PermissionRead read-only access
+	PermissionRead PermissionType = 2
+	// PermissionReadWrite read-write access
+	PermissionReadWrite PermissionType = 3
+)
+
+// MarshalJSON encodes t as a string.
+func (t GUIAccess) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *GUIAccess) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var guiAccessNames = enumNames{
+	kind: "frontend access",
+	names: map[int][]string{
+		int(GUIAccessDefault):  {"Default", "System default"},
+		int(GUIAccessInternal): {"Internal"},
+		int(GUIAccessLDAP):     {"LDAP"},
+		int(GUIAccessDisabled): {"Disabled"},
+	},
+}
+
+func (t GUIAccess) String() string {
+	return guiAccessNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t GUIAccess) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *GUIAccess) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseGUIAccess(string(text))
+	return
+}
+
+// ParseGUIAccess Parses a frontend access from its display name or number.
+func ParseGUIAccess(s string) (GUIAccess, error) {
+	v, err := guiAccessNames.parse(s)
+	return GUIAccess(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t DebugModeType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *DebugModeType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var debugModeTypeNames = enumNames{
+	kind: "debug mode",
+	names: map[int][]string{
+		int(DebugModeDisabled): {"Disabled"},
+		int(DebugModeEnabled):  {"Enabled"},
+	},
+}
+
+func (t DebugModeType) String() string {
+	return debugModeTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t DebugModeType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *DebugModeType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseDebugModeType(string(text))
+	return
+}
+
+// ParseDebugModeType Parses a debug mode from its display name or number.
+func ParseDebugModeType(s string) (DebugModeType, error) {
+	v, err := debugModeTypeNames.parse(s)
+	return DebugModeType(v), err
+}
+
+// MarshalJSON encodes t as a string.
+func (t PermissionType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *PermissionType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var permissionTypeNames = enumNames{
+	kind: "permission",
+	names: map[int][]string{
+		int(PermissionDeny):      {"Deny"},
+		int(PermissionRead):      {"Read", "Read-only"},
+		int(PermissionReadWrite): {"Read-write"},
+	},
+}
+
+func (t PermissionType) String() string {
+	return permissionTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t PermissionType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *PermissionType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParsePermissionType(string(text))
+	return
+}
+
+// ParsePermissionType Parses a permission from its display name or number.
+func ParsePermissionType(s string) (PermissionType, error) {
+	v, err := permissionTypeNames.parse(s)
+	return PermissionType(v), err
+}
+
+// UserGroupPermission represent Zabbix permission object, the access of a user group to the hosts or templates of a group
+// https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/object#permission
+type UserGroupPermission struct {
+	ID         string         `json:"id"` // host group or template group ID
+	Permission PermissionType `json:"permission"`
+}
+
+// UserGroupPermissions is an array of UserGroupPermission
+type UserGroupPermissions []UserGroupPermission
+
+// UserGroupTagFilter represent Zabbix tag based permission object, restricting the problems a user group sees in a host group
+// https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/object#tag-based_permission
+type UserGroupTagFilter struct {
+	GroupID string `json:"groupid"`
+	Tag     string `json:"tag"`   // empty for all tags
+	Value   string `json:"value"` // empty for all values
+}
+
+// UserGroupTagFilters is an array of UserGroupTagFilter
+type UserGroupTagFilters []UserGroupTagFilter
+
+// UserID represent Zabbix user ID, use with user group creation
+type UserID struct {
+	UserID string `json:"userid"`
+}
+
+// UserIDs is an array of UserID
+type UserIDs []UserID
+
 // UserGroup represent Zabbix user group object
 // https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/object
 type UserGroup struct {
-	GroupID    string        `json:"usrgrpid,omitempty"`
-	Name       string        `json:"name"`
-	DebugMode  DebugModeType `json:"debug_mode,string,omitempty"`
-	GuiAccess  int           `json:"gui_access,string,omitempty"`
-	UserStatus StatusType    `json:"users_status,string,omitempty"`
+	GroupID string `json:"usrgrpid,omitempty"`
+	Name    string `json:"name"`
+
+	// The settings below are left unchanged on update when zero.
+	DebugMode  DebugModeType `json:"debug_mode,omitempty"`
+	GuiAccess  int           `json:"gui_access,string,omitempty"` // one of the GUIAccess values
+	UserStatus StatusType    `json:"users_status,omitempty"`
+
+	// Rights on host groups, returned with selectRights before 6.2 and selectHostGroupRights since then.
+	// NOTE: sent as rights before 6.2
+	HostGroupRights UserGroupPermissions `json:"hostgroup_rights,omitempty"`
+	// Rights on template groups, returned with selectTemplateGroupRights.
+	// NOTE: new in 6.2, sent with the host group rights before, templates being in host groups
+	TemplateGroupRights UserGroupPermissions `json:"templategroup_rights,omitempty"`
+
+	// TagFilters are returned with selectTagFilters.
+	TagFilters UserGroupTagFilters `json:"tag_filters,omitempty"`
+
+	// Users of the group, returned with selectUsers.
+	// NOTE: sent as userids before 5.2
+	Users UserIDs `json:"users,omitempty"`
 }
 
 // UserGroups is an array of UserGroup
 type UserGroups []UserGroup
 
+// UserGroupID represent Zabbix user group ID, use with user creation
+type UserGroupID struct {
+	GroupID string `json:"usrgrpid"`
+}
+
+// UserGroupIDs is an array of UserGroupID
+type UserGroupIDs []UserGroupID
+
+var userGroupFields = fieldRules{
+	{Field: "hostgroup_rights", Feature: FeatureTemplateGroups, Legacy: "rights"},
+	{Field: "users", Feature: FeatureUserGroupUsers, Legacy: "userids", Convert: objectIDs("userid")},
+}
+
+// UnmarshalJSON decodes the rights of the user group whatever the server version.
+func (g *UserGroup) UnmarshalJSON(data []byte) (err error) {
+	if data, err = userGroupFields.canonical(data); err != nil {
+		return
+	}
+	type userGroup UserGroup
+	return unmarshalFlexible(data, (*userGroup)(g))
+}
+
+// userGroupParams returns the parameters of usergroup.create and usergroup.update for the server version.
+func (api *API) userGroupParams(groups UserGroups) (params interface{}, err error) {
+	if params, err = api.marshalFor(groups, userGroupFields); err != nil || !api.legacy(FeatureTemplateGroups) {
+		return
+	}
+	for _, o := range params.([]interface{}) {
+		object := o.(map[string]interface{})
+		if rights, ok := object["templategroup_rights"].([]interface{}); ok {
+			hostRights, _ := object["rights"].([]interface{})
+			object["rights"] = append(hostRights, rights...)
+		}
+		delete(object, "templategroup_rights")
+	}
+	return
+}
+
+// selectUserGroup returns the parameters selecting the rights, tag filters and users of user groups.
+func (api *API) selectUserGroup(params Params) Params {
+	if api.Supports(FeatureTemplateGroups) {
+		params["selectHostGroupRights"] = "extend"
+		params["selectTemplateGroupRights"] = "extend"
+	} else {
+		params["selectRights"] = "extend"
+	}
+	params["selectTagFilters"] = "extend"
+	params["selectUsers"] = []string{"userid"}
+	return params
+}
+
 // UserGroupsGet Wrapper for usergroup.get
 // https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/get
 func (api *API) UserGroupsGet(params Params) (res UserGroups, err error) {
@@ -33,3 +277,82 @@ func (api *API) UserGroupsGet(params Params) (res UserGroups, err error) {
 	err = api.CallWithErrorParse("usergroup.get", params, &res)
 	return
 }
+
+// UserGroupGetByID Gets user group by Id only if there is exactly 1 matching user group.
+// Its rights, tag filters and users are selected.
+func (api *API) UserGroupGetByID(id string) (res *UserGroup, err error) {
+	groups, err := api.UserGroupsGet(api.selectUserGroup(Params{"usrgrpids": id}))
+	if err != nil {
+		return
+	}
+
+	if len(groups) == 1 {
+		res = &groups[0]
+	} else {
+		e := ExpectedOneResult(len(groups))
+		err = &e
+	}
+	return
+}
+
+// UserGroupsCreate Wrapper for usergroup.create
+// https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/create
+func (api *API) UserGroupsCreate(groups UserGroups) (err error) {
+	params, err := api.userGroupParams(groups)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("usergroup.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "usrgrpids") {
+		groups[i].GroupID = id
+	}
+	return
+}
+
+// UserGroupsUpdate Wrapper for usergroup.update
+// Rights, tag filters and users given replace those of the user group.
+// https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/update
+func (api *API) UserGroupsUpdate(groups UserGroups) (err error) {
+	params, err := api.userGroupParams(groups)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("usergroup.update", params)
+	return
+}
+
+// UserGroupsDelete Wrapper for usergroup.delete
+// Cleans GroupID in all user groups elements if call succeed.
+// https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/delete
+func (api *API) UserGroupsDelete(groups UserGroups) (err error) {
+	ids := make([]string, len(groups))
+	for i, group := range groups {
+		ids[i] = group.GroupID
+	}
+
+	err = api.UserGroupsDeleteByIds(ids)
+	if err == nil {
+		for i := range groups {
+			groups[i].GroupID = ""
+		}
+	}
+	return
+}
+
+// UserGroupsDeleteByIds Wrapper for usergroup.delete
+// https://www.zabbix.com/documentation/4.0/manual/api/reference/usergroup/delete
+func (api *API) UserGroupsDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("usergroup.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "usrgrpids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
@@ -1,6 +1,11 @@
 package zabbix_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
 	"testing"
 
 	zapi "github.com/claranet/go-zabbix-api"
@@ -22,3 +27,128 @@ func TestUserGroupsGet(t *testing.T) {
 		t.Errorf("Bad user groups: %#v", userGroups)
 	}
 }
+
+func TestUserGroups(t *testing.T) {
+	api := testGetAPI(t)
+
+	hostGroup := testCreateHostGroup(t)
+	defer testDeleteHostGroup(hostGroup, t)
+
+	groups := zapi.UserGroups{{
+		Name:            fmt.Sprintf("zabbix-testing-%d", rand.Int()),
+		GuiAccess:       int(zapi.GUIAccessDisabled),
+		DebugMode:       zapi.DebugModeEnabled,
+		HostGroupRights: zapi.UserGroupPermissions{{ID: hostGroup.GroupID, Permission: zapi.PermissionRead}},
+		TagFilters:      zapi.UserGroupTagFilters{{GroupID: hostGroup.GroupID, Tag: "team", Value: "ops"}},
+	}}
+	// Zabbix v6.2 introduced Template Groups and their rights
+	if api.Supports(zapi.FeatureTemplateGroups) {
+		templateGroup := testCreateTemplateGroup(t)
+		defer testDeleteTemplateGroup(templateGroup, t)
+		groups[0].TemplateGroupRights = zapi.UserGroupPermissions{{ID: templateGroup.GroupID, Permission: zapi.PermissionReadWrite}}
+	}
+	err := api.UserGroupsCreate(groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	group := &groups[0]
+	if group.GroupID == "" {
+		t.Errorf("User group ID is empty: %#v", group)
+	}
+
+	users := zapi.Users{{
+		Username:   fmt.Sprintf("zabbix-testing-%d", rand.Int()),
+		Password:   "Zabbix-testing-1234!",
+		Type:       zapi.ZabbixUser,
+		UserGroups: zapi.UserGroupIDs{{GroupID: group.GroupID}},
+	}}
+	if err = api.UsersCreate(users); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if users[0].UserID == "" {
+			return
+		}
+		if err := api.UsersDelete(users); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	group2, err := api.UserGroupGetByID(group.GroupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group2.Name != group.Name || group2.GuiAccess != int(zapi.GUIAccessDisabled) || group2.DebugMode != zapi.DebugModeEnabled {
+		t.Fatalf("User groups are not equal:\n%#v\n%#v", group, group2)
+	}
+	if !reflect.DeepEqual(group2.HostGroupRights, group.HostGroupRights) || !reflect.DeepEqual(group2.TemplateGroupRights, group.TemplateGroupRights) {
+		t.Errorf("Unexpected rights: %#v", group2)
+	}
+	if !reflect.DeepEqual(group2.TagFilters, group.TagFilters) {
+		t.Errorf("Unexpected tag filters: %#v", group2.TagFilters)
+	}
+	if len(group2.Users) != 1 || group2.Users[0].UserID != users[0].UserID {
+		t.Errorf("Unexpected users: %#v", group2.Users)
+	}
+
+	group2.UserStatus = zapi.Disabled
+	group2.GuiAccess = int(zapi.GUIAccessInternal)
+	group2.HostGroupRights[0].Permission = zapi.PermissionDeny
+	err = api.UserGroupsUpdate(zapi.UserGroups{*group2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	group3, err := api.UserGroupGetByID(group.GroupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group3.UserStatus != zapi.Disabled || group3.GuiAccess != int(zapi.GUIAccessInternal) || group3.DebugMode != zapi.DebugModeEnabled || group3.HostGroupRights[0].Permission != zapi.PermissionDeny {
+		t.Errorf("User group is not updated: %#v", group3)
+	}
+
+	// the group of the user cannot be deleted before the user
+	if err = api.UsersDelete(users); err != nil {
+		t.Fatal(err)
+	}
+	err = api.UserGroupsDelete(groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.GroupID != "" {
+		t.Errorf("User group ID is not cleaned: %#v", group)
+	}
+}
+
+func TestUserGroupPartialUpdate(t *testing.T) {
+	var sent []map[string]interface{}
+	api := testFakeAPI(t, "6.0.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		if err := json.Unmarshal(params, &sent); err != nil {
+			t.Fatal(err)
+		}
+		return `{"usrgrpids":["1"]}`, nil
+	})
+
+	if err := api.UserGroupsUpdate(zapi.UserGroups{{GroupID: "1", Name: "renamed"}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"debug_mode", "gui_access", "users_status"} {
+		if value, present := sent[0][key]; present {
+			t.Errorf("%s is sent on a partial update: %v", key, value)
+		}
+	}
+
+	if err := api.UserGroupsUpdate(zapi.UserGroups{{GroupID: "1", GuiAccess: int(zapi.GUIAccessDisabled), UserStatus: zapi.Disabled}}); err != nil {
+		t.Fatal(err)
+	}
+	if sent[0]["gui_access"] != "3" || sent[0]["users_status"] != "1" {
+		t.Errorf("Unexpected settings: %v", sent[0])
+	}
+
+	var group zapi.UserGroup
+	if err := json.Unmarshal([]byte(`{"usrgrpid":"1","gui_access":"3","debug_mode":"1","users_status":"1"}`), &group); err != nil {
+		t.Fatal(err)
+	}
+	if group.GuiAccess != int(zapi.GUIAccessDisabled) || group.DebugMode != zapi.DebugModeEnabled || group.UserStatus != zapi.Disabled {
+		t.Errorf("Unexpected user group: %#v", group)
+	}
+}
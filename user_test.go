@@ -1,6 +1,11 @@
 package zabbix_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
 	"testing"
 
 	zapi "github.com/claranet/go-zabbix-api"
@@ -20,6 +25,120 @@ func TestUsersGet(t *testing.T) {
 		t.Fatal(err)
 	}
 	if len(users) != 1 {
-		t.Errorf("Bad users: %#v", users)
+		t.Fatalf("Bad users: %#v", users)
+	}
+	if users[0].Username != "Admin" {
+		t.Errorf("Username is %q and should be %q", users[0].Username, "Admin")
+	}
+}
+
+func TestUsers(t *testing.T) {
+	api := testGetAPI(t)
+
+	groups, err := api.UserGroupsGet(zapi.Params{"filter": map[string]string{"name": "Zabbix administrators"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("Bad user groups: %#v", groups)
+	}
+
+	users := zapi.Users{{
+		Username:    fmt.Sprintf("zabbix-testing-%d", rand.Int()),
+		Name:        "Testing",
+		Password:    "Zabbix-testing-1234!",
+		Type:        zapi.ZabbixAdmin,
+		AutoLogout:  "0",
+		Lang:        "en_US",
+		RowsPerPage: 100,
+		UserGroups:  zapi.UserGroupIDs{{GroupID: groups[0].GroupID}},
+	}}
+	if api.Supports(zapi.FeatureUserRoles) {
+		users[0].Timezone = "Europe/Paris"
+	}
+	err = api.UsersCreate(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &users[0]
+	if user.UserID == "" {
+		t.Errorf("User ID is empty: %#v", user)
+	}
+
+	user2, err := api.UserGetByUsername(user.Username)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user2.UserID != user.UserID || user2.Type != zapi.ZabbixAdmin || user2.RowsPerPage != 100 || user2.Timezone != user.Timezone {
+		t.Errorf("Users are not equal:\n%#v\n%#v", user, user2)
+	}
+	if !reflect.DeepEqual(user2.UserGroups, user.UserGroups) {
+		t.Errorf("Unexpected user groups: %#v", user2.UserGroups)
+	}
+
+	user2.Name = "Updated"
+	err = api.UsersUpdate(zapi.Users{*user2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user2, err = api.UserGetByID(user.UserID); err != nil {
+		t.Fatal(err)
+	}
+	if user2.Name != "Updated" {
+		t.Errorf("User is not updated: %#v", user2)
+	}
+
+	err = api.UsersUnblock([]string{user.UserID})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = api.UsersDelete(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.UserID != "" {
+		t.Errorf("User ID is not cleaned: %#v", user)
+	}
+}
+
+func TestUserRoleParams(t *testing.T) {
+	var sent []map[string]interface{}
+	api := testFakeAPI(t, "5.0.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		if err := json.Unmarshal(params, &sent); err != nil {
+			t.Error(err)
+		}
+		return `{"userids":["1"]}`, nil
+	})
+
+	if err := api.UsersUpdate(zapi.Users{{UserID: "1", Username: "a", RoleID: "3"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, present := sent[0]["roleid"]; present || sent[0]["type"] != "3" {
+		t.Errorf("Default role should be sent as its type before 5.2, got %v", sent[0])
+	}
+
+	err := api.UsersUpdate(zapi.Users{{UserID: "1", Username: "a", RoleID: "5"}})
+	if _, ok := err.(*zapi.UnsupportedFeature); !ok {
+		t.Errorf("Expected an UnsupportedFeature error for a custom role before 5.2, got %v", err)
+	}
+}
+
+func TestUnknownVersionParams(t *testing.T) {
+	var sent []map[string]interface{}
+	api := testFakeAPI(t, "7.0.0", func(r *http.Request, method string, params json.RawMessage) (string, *zapi.Error) {
+		if err := json.Unmarshal(params, &sent); err != nil {
+			t.Error(err)
+		}
+		return `{"userids":["1"]}`, nil
+	})
+	api.ServerVersion = nil
+
+	if err := api.UsersUpdate(zapi.Users{{UserID: "1", Username: "a", RoleID: "5", Timezone: "UTC"}}); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"userid": "1", "username": "a", "roleid": "5", "timezone": "UTC"}
+	if !reflect.DeepEqual(sent[0], expected) {
+		t.Errorf("Users should be sent untouched to a server of unknown version, got %v", sent[0])
 	}
 }
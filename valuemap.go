@@ -0,0 +1,283 @@
+package zabbix
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ValueMappingType how a value map mapping matches values, new in 6.0
+	// see "type" in https://www.zabbix.com/documentation/6.0/en/manual/api/reference/valuemap/object#value-mappings
+	ValueMappingType int
+)
+
+const (
+	// MappingEqual (default) value equal to the mapping value
+	MappingEqual ValueMappingType = 0
+	// MappingGreaterOrEqual numeric value greater than or equal to the mapping value
+	MappingGreaterOrEqual ValueMappingType = 1
+	// MappingLessOrEqual numeric value less than or equal to the mapping value
+	MappingLessOrEqual ValueMappingType = 2
+	// MappingInRange numeric value within the ranges of the mapping value, such as "1-10,20,-5--1"
+	MappingInRange ValueMappingType = 3
+	// MappingRegexp character value matching the regular expression of the mapping value
+	MappingRegexp ValueMappingType = 4
+	// MappingDefault value not matched by other mappings
+	MappingDefault ValueMappingType = 5
+)
+
+// MarshalJSON encodes t as a string.
+func (t ValueMappingType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(int(t))
+}
+
+// UnmarshalJSON accepts strings, numbers, empty strings and null.
+func (t *ValueMappingType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, (*int)(t))
+}
+
+var valueMappingTypeNames = enumNames{
+	kind: "mapping type",
+	names: map[int][]string{
+		int(MappingEqual):          {"Equals", "Equal"},
+		int(MappingGreaterOrEqual): {"Is greater than or equals", "Greater or equal"},
+		int(MappingLessOrEqual):    {"Is less than or equals", "Less or equal"},
+		int(MappingInRange):        {"In range"},
+		int(MappingRegexp):         {"Regexp", "Regular expression"},
+		int(MappingDefault):        {"Default"},
+	},
+}
+
+func (t ValueMappingType) String() string {
+	return valueMappingTypeNames.format(int(t))
+}
+
+// MarshalText encodes t as its display name.
+func (t ValueMappingType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText accepts a display name or a number.
+func (t *ValueMappingType) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseValueMappingType(string(text))
+	return
+}
+
+// ParseValueMappingType Parses a mapping type from its display name or number.
+func ParseValueMappingType(s string) (ValueMappingType, error) {
+	v, err := valueMappingTypeNames.parse(s)
+	return ValueMappingType(v), err
+}
+
+// ValueMapping represent Zabbix value map mapping object
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/valuemap/object#value-mappings
+type ValueMapping struct {
+	// NOTE: new in 6.0, only MappingEqual is supported before
+	Type     ValueMappingType `json:"type,omitempty"`
+	Value    string           `json:"value"`
+	NewValue string           `json:"newvalue"`
+}
+
+// ValueMappings is an array of ValueMapping
+type ValueMappings []ValueMapping
+
+// ValueMap represent Zabbix value map object
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/valuemap/object
+type ValueMap struct {
+	ValueMapID string `json:"valuemapid,omitempty"`
+	Name       string `json:"name"`
+	// HostID is the host or template of the value map, required from 5.4.
+	// NOTE: new in 5.4, value maps are global before
+	HostID string `json:"hostid,omitempty"`
+	// Mappings are returned with selectMappings.
+	Mappings ValueMappings `json:"mappings,omitempty"`
+}
+
+// ValueMaps is an array of ValueMap
+type ValueMaps []ValueMap
+
+var valueMapFields = fieldRules{
+	{Field: "hostid", Feature: FeatureHostValueMaps},
+}
+
+// valueMapParams returns the parameters of valuemap.create and valuemap.update for the server version.
+func (api *API) valueMapParams(valueMaps ValueMaps) (params interface{}, err error) {
+	if api.legacy(FeatureValueMapTypes) {
+		for _, m := range valueMaps {
+			for _, mapping := range m.Mappings {
+				if mapping.Type != MappingEqual {
+					return nil, &UnsupportedFeature{FeatureValueMapTypes, api.ServerVersion}
+				}
+			}
+		}
+	}
+	return api.marshalFor(valueMaps, valueMapFields)
+}
+
+// ValueMapsGet Wrapper for valuemap.get
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/valuemap/get
+func (api *API) ValueMapsGet(params Params) (res ValueMaps, err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	err = api.CallWithErrorParse("valuemap.get", params, &res)
+	return
+}
+
+// ValueMapGetByID Gets value map by Id only if there is exactly 1 matching value map.
+// Its mappings are selected.
+func (api *API) ValueMapGetByID(id string) (res *ValueMap, err error) {
+	valueMaps, err := api.ValueMapsGet(Params{"valuemapids": id, "selectMappings": "extend"})
+	if err != nil {
+		return
+	}
+
+	if len(valueMaps) == 1 {
+		res = &valueMaps[0]
+	} else {
+		e := ExpectedOneResult(len(valueMaps))
+		err = &e
+	}
+	return
+}
+
+// ValueMapsCreate Wrapper for valuemap.create
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/valuemap/create
+func (api *API) ValueMapsCreate(valueMaps ValueMaps) (err error) {
+	params, err := api.valueMapParams(valueMaps)
+	if err != nil {
+		return
+	}
+	response, err := api.CallWithError("valuemap.create", params)
+	if err != nil {
+		return
+	}
+
+	for i, id := range resultIDs(response.Result, "valuemapids") {
+		valueMaps[i].ValueMapID = id
+	}
+	return
+}
+
+// ValueMapsUpdate Wrapper for valuemap.update
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/valuemap/update
+func (api *API) ValueMapsUpdate(valueMaps ValueMaps) (err error) {
+	params, err := api.valueMapParams(valueMaps)
+	if err != nil {
+		return
+	}
+	_, err = api.CallWithError("valuemap.update", params)
+	return
+}
+
+// ValueMapsDelete Wrapper for valuemap.delete
+// Cleans ValueMapID in all valueMaps elements if call succeed.
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/valuemap/delete
+func (api *API) ValueMapsDelete(valueMaps ValueMaps) (err error) {
+	ids := make([]string, len(valueMaps))
+	for i, valueMap := range valueMaps {
+		ids[i] = valueMap.ValueMapID
+	}
+
+	err = api.ValueMapsDeleteByIds(ids)
+	if err == nil {
+		for i := range valueMaps {
+			valueMaps[i].ValueMapID = ""
+		}
+	}
+	return
+}
+
+// ValueMapsDeleteByIds Wrapper for valuemap.delete
+// https://www.zabbix.com/documentation/6.0/en/manual/api/reference/valuemap/delete
+func (api *API) ValueMapsDeleteByIds(ids []string) (err error) {
+	response, err := api.CallWithError("valuemap.delete", ids)
+	if err != nil {
+		return
+	}
+
+	if deleted := resultIDs(response.Result, "valuemapids"); len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// Resolve Maps a raw value of an item of the given value type the way the frontend does,
+// returning false when no mapping applies.
+// Character values are compared as text and matched by regular expressions,
+// numeric values are compared as numbers and matched by ranges.
+// Log and Text items are never mapped.
+// Regular expressions are compiled with the Go syntax rather than PCRE as Zabbix does,
+// an error being returned for those it cannot compile, such as ones with lookarounds or backreferences.
+func (m *ValueMap) Resolve(valueType ValueType, value string) (res string, ok bool, err error) {
+	numeric := valueType == Float || valueType == Unsigned
+	if !numeric && valueType != Character {
+		return
+	}
+	number, parseErr := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if numeric && parseErr != nil {
+		return
+	}
+
+	def, hasDefault := "", false
+	for _, mapping := range m.Mappings {
+		matched := false
+		switch mapping.Type {
+		case MappingEqual:
+			if numeric {
+				v, err := strconv.ParseFloat(mapping.Value, 64)
+				matched = err == nil && v == number
+			} else {
+				matched = mapping.Value == value
+			}
+		case MappingGreaterOrEqual, MappingLessOrEqual:
+			if v, err := strconv.ParseFloat(mapping.Value, 64); numeric && err == nil {
+				matched = (mapping.Type == MappingGreaterOrEqual && number >= v) ||
+					(mapping.Type == MappingLessOrEqual && number <= v)
+			}
+		case MappingInRange:
+			matched = numeric && inRanges(mapping.Value, number)
+		case MappingRegexp:
+			if numeric {
+				break
+			}
+			var re *regexp.Regexp
+			if re, err = regexp.Compile(mapping.Value); err != nil {
+				return "", false, fmt.Errorf("zabbix: unsupported regular expression %q in value map %q: %v", mapping.Value, m.Name, err)
+			}
+			matched = re.MatchString(value)
+		case MappingDefault:
+			def, hasDefault = mapping.NewValue, true
+		}
+		if matched {
+			return mapping.NewValue, true, nil
+		}
+	}
+	return def, hasDefault, nil
+}
+
+// inRanges tells if n is within ranges such as "1-10,20,-5--1", bounds included.
+func inRanges(ranges string, n float64) bool {
+	for _, r := range strings.Split(ranges, ",") {
+		r = strings.TrimSpace(r)
+		from, to := r, r
+		// the separator is the first dash following a digit, the others being signs
+		for i := 1; i < len(r); i++ {
+			if r[i] == '-' && (r[i-1] >= '0' && r[i-1] <= '9' || r[i-1] == '.') {
+				from, to = strings.TrimSpace(r[:i]), strings.TrimSpace(r[i+1:])
+				break
+			}
+		}
+		lo, err := strconv.ParseFloat(from, 64)
+		if err != nil {
+			continue
+		}
+		hi, err := strconv.ParseFloat(to, 64)
+		if err == nil && lo <= n && n <= hi {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package zabbix_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	zapi "github.com/claranet/go-zabbix-api"
+)
+
+func testCreateValueMap(host *zapi.Host, t *testing.T) *zapi.ValueMap {
+	api := testGetAPI(t)
+
+	valueMaps := zapi.ValueMaps{{
+		Name: fmt.Sprintf("zabbix-testing-%d", rand.Int()),
+		Mappings: zapi.ValueMappings{
+			{Value: "0", NewValue: "Down"},
+			{Value: "1", NewValue: "Up"},
+		},
+	}}
+	if api.Supports(zapi.FeatureHostValueMaps) {
+		valueMaps[0].HostID = host.HostID
+	}
+	err := api.ValueMapsCreate(valueMaps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &valueMaps[0]
+}
+
+func testDeleteValueMap(valueMap *zapi.ValueMap, t *testing.T) {
+	err := testGetAPI(t).ValueMapsDelete(zapi.ValueMaps{*valueMap})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValueMaps(t *testing.T) {
+	api := testGetAPI(t)
+
+	group := testCreateHostGroup(t)
+	defer testDeleteHostGroup(group, t)
+
+	host := testCreateHost(group, t)
+	defer testDeleteHost(host, t)
+
+	valueMap := testCreateValueMap(host, t)
+	if valueMap.ValueMapID == "" {
+		t.Errorf("Value map ID is empty: %#v", valueMap)
+	}
+
+	valueMap2, err := api.ValueMapGetByID(valueMap.ValueMapID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valueMap2.Name != valueMap.Name || !reflect.DeepEqual(valueMap2.Mappings, valueMap.Mappings) {
+		t.Errorf("Value maps are not equal:\n%#v\n%#v", valueMap, valueMap2)
+	}
+
+	valueMap.Mappings = append(valueMap.Mappings, zapi.ValueMapping{Type: zapi.MappingDefault, NewValue: "Unknown"})
+	err = api.ValueMapsUpdate(zapi.ValueMaps{*valueMap})
+	if api.Supports(zapi.FeatureValueMapTypes) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	} else if _, ok := err.(*zapi.UnsupportedFeature); !ok {
+		t.Errorf("Expected an UnsupportedFeature error on Zabbix %s, got %v", api.ServerVersion, err)
+	}
+
+	testDeleteValueMap(valueMap, t)
+}
+
+func TestValueMapResolve(t *testing.T) {
+	valueMap := zapi.ValueMap{Mappings: zapi.ValueMappings{
+		{Value: "1", NewValue: "Up"},
+		{Type: zapi.MappingInRange, Value: "-10--5, 2-4", NewValue: "Ranged"},
+		{Type: zapi.MappingGreaterOrEqual, Value: "100", NewValue: "High"},
+		{Type: zapi.MappingRegexp, Value: "^err", NewValue: "Error"},
+		{Type: zapi.MappingDefault, NewValue: "Other"},
+	}}
+
+	cases := []struct {
+		valueType zapi.ValueType
+		value     string
+		expected  string
+		ok        bool
+	}{
+		{zapi.Unsigned, "1", "Up", true},
+		{zapi.Float, "1.0000", "Up", true},
+		{zapi.Float, "-7.5", "Ranged", true},
+		{zapi.Unsigned, "3", "Ranged", true},
+		{zapi.Unsigned, "250", "High", true},
+		{zapi.Unsigned, "50", "Other", true},
+		{zapi.Character, "1", "Up", true},
+		{zapi.Character, "1.0", "Other", true},
+		{zapi.Character, "error: timeout", "Error", true},
+		{zapi.Character, "300", "Other", true},
+		{zapi.Text, "1", "", false},
+	}
+	for _, c := range cases {
+		s, ok, err := valueMap.Resolve(c.valueType, c.value)
+		if s != c.expected || ok != c.ok || err != nil {
+			t.Errorf("Resolve(%s, %q) = %q, %t, %v, expected %q, %t", c.valueType, c.value, s, ok, err, c.expected, c.ok)
+		}
+	}
+
+	// PCRE lookarounds are not supported by Go regular expressions
+	valueMap.Mappings = append(zapi.ValueMappings{{Type: zapi.MappingRegexp, Value: "^(?!ok)", NewValue: "Not OK"}}, valueMap.Mappings...)
+	if _, _, err := valueMap.Resolve(zapi.Character, "failed"); err == nil {
+		t.Error("Expected an error for a regular expression Go cannot compile")
+	}
+	if s, ok, err := valueMap.Resolve(zapi.Unsigned, "1"); s != "Up" || !ok || err != nil {
+		t.Errorf("Numeric values should not be matched by regular expressions, got %q, %t, %v", s, ok, err)
+	}
+}